@@ -14,14 +14,18 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
 	"os"
+	"os/signal"
 	"strings"
-	"time"
+	"syscall"
 
 	"github.com/alecthomas/kingpin/v2"
+	"github.com/blang/semver/v4"
 	"github.com/prometheus-community/postgres_exporter/collector"
 	"github.com/prometheus-community/postgres_exporter/config"
 	"github.com/prometheus/client_golang/prometheus"
@@ -34,7 +38,7 @@ import (
 	"github.com/prometheus/exporter-toolkit/web/kingpinflag"
 )
 
-func registerPostgresCollector(dsn string, exporter *Exporter, logger *slog.Logger, excludedDatabases []string, scrapeTimeout time.Duration, concurrentScrape bool) {
+func registerPostgresCollector(dsn string, exporter *Exporter, logger *slog.Logger, excludedDatabases []string, concurrentScrape bool, collectorOpts []collector.Option, instanceOpts []collector.InstanceOpt) {
 	if dsn == "" {
 		return
 	}
@@ -43,7 +47,7 @@ func registerPostgresCollector(dsn string, exporter *Exporter, logger *slog.Logg
 
 	if concurrentScrape {
 		// Original behavior: dedicated instance for collector, creates new connection per scrape
-		template, err := collector.NewInstance(dsn)
+		template, err := collector.NewInstance(dsn, instanceOpts...)
 		if err != nil {
 			logger.Warn("Failed to create template instance", "err", err.Error())
 			return
@@ -57,7 +61,7 @@ func registerPostgresCollector(dsn string, exporter *Exporter, logger *slog.Logg
 				return nil, err
 			}
 
-			inst, err := collector.NewInstance(dsn)
+			inst, err := collector.NewInstance(dsn, instanceOpts...)
 			if err != nil {
 				return nil, err
 			}
@@ -77,13 +81,17 @@ func registerPostgresCollector(dsn string, exporter *Exporter, logger *slog.Logg
 		excludedDatabases,
 		factory,
 		[]string{},
-		collector.WithTimeout(scrapeTimeout),
+		collectorOpts...,
 	)
 	if err != nil {
 		logger.Warn("Failed to create PostgresCollector", "err", err.Error())
 		return
 	}
 
+	if cluster, ok := exporter.clusterLabel(dsn); ok {
+		prometheus.WrapRegistererWith(prometheus.Labels{clusterLabelName: cluster}, prometheus.DefaultRegisterer).MustRegister(pe)
+		return
+	}
 	prometheus.MustRegister(pe)
 }
 
@@ -101,11 +109,20 @@ var (
 	queriesPath            = kingpin.Flag("extend.query-path", "Path to custom queries to run. (DEPRECATED)").Default("").Envar("PG_EXPORTER_EXTEND_QUERY_PATH").String()
 	onlyDumpMaps           = kingpin.Flag("dumpmaps", "Do not run, simply dump the maps.").Bool()
 	constantLabelsList     = kingpin.Flag("constantLabels", "A list of label=value separated by comma(,). (DEPRECATED)").Default("").Envar("PG_EXPORTER_CONSTANT_LABELS").String()
+	dsnLabelsList          = kingpin.Flag("config.dsn-labels", "A list of host:port=cluster separated by comma(,) used to add a cluster label to the metrics of each data source.").Default("").Envar("PG_EXPORTER_DSN_LABELS").String()
 	excludeDatabases       = kingpin.Flag("exclude-databases", "A list of databases to remove when autoDiscoverDatabases is enabled (DEPRECATED)").Default("").Envar("PG_EXPORTER_EXCLUDE_DATABASES").String()
 	includeDatabases       = kingpin.Flag("include-databases", "A list of databases to include when autoDiscoverDatabases is enabled (DEPRECATED)").Default("").Envar("PG_EXPORTER_INCLUDE_DATABASES").String()
 	metricPrefix           = kingpin.Flag("metric-prefix", "A metric prefix can be used to have non-default (not \"pg\") prefixes for each of the metrics").Default("pg").Envar("PG_EXPORTER_METRIC_PREFIX").String()
 	scrapeTimeout          = kingpin.Flag("scrape-timeout", "Maximum time for a scrape to complete before timing out (0 = no timeout)").Default("0").Envar("PG_EXPORTER_SCRAPE_TIMEOUT").Duration()
+	scrapeDeadline         = kingpin.Flag("scrape.deadline", "Maximum time for a scrape; collectors still running are cancelled and reported as timed out while already collected metrics are returned (0 = no deadline)").Default("0").Envar("PG_EXPORTER_SCRAPE_DEADLINE").Duration()
 	concurrentScrape       = kingpin.Flag("concurrent-scrape", "Use dedicated instance for collector allowing concurrent scrapes (default: true for backward compatibility)").Default("true").Envar("PG_EXPORTER_CONCURRENT_SCRAPE").Bool()
+	versionOverride        = kingpin.Flag("database.version-override", "PostgreSQL version to assume instead of querying the server, for poolers that do not forward SELECT version()").Default("").Envar("PG_EXPORTER_DATABASE_VERSION_OVERRIDE").String()
+	sslMode                = kingpin.Flag("database.sslmode", "sslmode to connect with when the DSN does not set one (disable, require, verify-ca, verify-full)").Default("").Envar("PG_EXPORTER_DATABASE_SSLMODE").String()
+	lockTimeout            = kingpin.Flag("database.lock-timeout", "lock_timeout to set on the exporter's own session, 0 to leave the server default").Default("0s").Envar("PG_EXPORTER_DATABASE_LOCK_TIMEOUT").Duration()
+	idleInTxTimeout        = kingpin.Flag("database.idle-in-transaction-timeout", "idle_in_transaction_session_timeout to set on the exporter's own session, 0 to leave the server default").Default("0s").Envar("PG_EXPORTER_DATABASE_IDLE_IN_TRANSACTION_TIMEOUT").Duration()
+	pingBeforeScrape       = kingpin.Flag("database.ping-before-scrape", "Check the database connection before running collectors and skip them if it is dead.").Default("false").Envar("PG_EXPORTER_DATABASE_PING_BEFORE_SCRAPE").Bool()
+	emitNaNOnError         = kingpin.Flag("metrics.emit-nan-on-error", "Emit NaN for the metrics of a failed collector instead of omitting them: every series it emitted on its last successful scrape, plus any unlabelled metrics it describes.").Default("false").Envar("PG_EXPORTER_METRICS_EMIT_NAN_ON_ERROR").Bool()
+	queryOverridesFile     = kingpin.Flag("collector.query-overrides-file", "YAML file mapping collector names to replacement SQL for their main query. The replacement must return the same columns, in the same order, as the built-in query.").Default("").Envar("PG_EXPORTER_COLLECTOR_QUERY_OVERRIDES_FILE").String()
 	logger                 = promslog.NewNopLogger()
 )
 
@@ -122,6 +139,8 @@ const (
 	staticLabelName = "static"
 	// Metric label used for server identification.
 	serverLabelName = "server"
+	// Metric label used for the human-friendly cluster name of a server.
+	clusterLabelName = "cluster"
 )
 
 func main() {
@@ -163,12 +182,44 @@ func main() {
 		logger.Warn("Constant labels on all metrics is DEPRECATED")
 	}
 
+	instanceOpts, err := instanceOptions()
+	if err != nil {
+		logger.Error("Invalid database options", "err", err.Error())
+		os.Exit(1)
+	}
+	for i, dsn := range dsns {
+		if *sslMode != "" {
+			dsn, err = config.WithDefaultSSLMode(dsn, *sslMode)
+			if err != nil {
+				logger.Error("Failed applying sslmode to data source", "err", err.Error())
+				os.Exit(1)
+			}
+		}
+		dsns[i], err = config.WithSessionTimeouts(dsn, *lockTimeout, *idleInTxTimeout)
+		if err != nil {
+			logger.Error("Failed applying session timeouts to data source", "err", err.Error())
+			os.Exit(1)
+		}
+	}
+	if *versionOverride != "" {
+		logger.Info("Using PostgreSQL version override instead of querying the server", "version", *versionOverride)
+	}
+
+	if *queryOverridesFile != "" {
+		if err := collector.LoadQueryOverrides(*queryOverridesFile); err != nil {
+			logger.Error("Failed loading query overrides", "err", err.Error())
+			os.Exit(1)
+		}
+		logger.Info("Loaded collector query overrides", "file", *queryOverridesFile)
+	}
+
 	opts := []ExporterOpt{
 		DisableDefaultMetrics(*disableDefaultMetrics),
 		DisableSettingsMetrics(*disableSettingsMetrics),
 		AutoDiscoverDatabases(*autoDiscoverDatabases),
 		WithUserQueriesPath(*queriesPath),
 		WithConstantLabels(*constantLabelsList),
+		WithDSNLabels(*dsnLabelsList),
 		ExcludeDatabases(excludedDatabases),
 		IncludeDatabases(*includeDatabases),
 		WithTimeout(*scrapeTimeout),
@@ -189,7 +240,13 @@ func main() {
 		dsn = dsns[0]
 	}
 
-	registerPostgresCollector(dsn, exporter, logger, excludedDatabases, *scrapeTimeout, *concurrentScrape)
+	collectorOpts := []collector.Option{
+		collector.WithTimeout(*scrapeTimeout),
+		collector.WithScrapeDeadline(*scrapeDeadline),
+		collector.WithNaNOnError(*emitNaNOnError),
+		collector.WithPingBeforeScrape(*pingBeforeScrape),
+	}
+	registerPostgresCollector(dsn, exporter, logger, excludedDatabases, *concurrentScrape, collectorOpts, instanceOpts)
 
 	http.Handle(*metricsPath, promhttp.Handler())
 
@@ -213,11 +270,47 @@ func main() {
 		http.Handle("/", landingPage)
 	}
 
-	http.HandleFunc("/probe", handleProbe(logger, excludedDatabases))
+	http.HandleFunc("/probe", handleProbe(logger, excludedDatabases, instanceOpts))
 
 	srv := &http.Server{}
-	if err := web.ListenAndServe(srv, webConfig, logger); err != nil {
+	// Stop serving on SIGTERM so that main returns and the deferred close of
+	// the database connections runs, instead of leaving them for the server
+	// to time out.
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGTERM, os.Interrupt)
+	go func() {
+		sig := <-sigs
+		logger.Info("Shutting down", "signal", sig.String())
+		if err := srv.Shutdown(context.Background()); err != nil {
+			logger.Error("Error shutting down HTTP server", "err", err)
+		}
+	}()
+	if err := web.ListenAndServe(srv, webConfig, logger); err != nil && !errors.Is(err, http.ErrServerClosed) {
 		logger.Error("Error running HTTP server", "err", err)
 		os.Exit(1)
 	}
 }
+
+// instanceOptions builds the collector instance options from the database.* flags.
+func instanceOptions() ([]collector.InstanceOpt, error) {
+	var opts []collector.InstanceOpt
+
+	if *versionOverride != "" {
+		v, err := semver.ParseTolerant(*versionOverride)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --database.version-override %q: %w", *versionOverride, err)
+		}
+		opts = append(opts, collector.WithVersionOverride(v))
+	}
+
+	if *sslMode != "" {
+		// The sslmode is added to the connection strings rather than passed
+		// to the instance; only validate it here so that probe-only setups
+		// fail at startup too.
+		if err := config.ValidateSSLMode(*sslMode); err != nil {
+			return nil, fmt.Errorf("invalid --database.sslmode: %w", err)
+		}
+	}
+
+	return opts, nil
+}
@@ -417,6 +417,7 @@ type Exporter struct {
 	dsn              []string
 	userQueriesPath  string
 	constantLabels   prometheus.Labels
+	dsnLabels        map[string]string
 	duration         prometheus.Gauge
 	error            prometheus.Gauge
 	psqlUp           prometheus.Gauge
@@ -509,6 +510,32 @@ func parseConstLabels(s string) prometheus.Labels {
 	return labels
 }
 
+// WithDSNLabels configures the cluster label of each data source, as a
+// comma separated list of host:port=cluster pairs. The host:port is matched
+// against the server fingerprint.
+func WithDSNLabels(s string) ExporterOpt {
+	return func(e *Exporter) {
+		e.dsnLabels = parseConstLabels(s)
+	}
+}
+
+// clusterLabel returns the cluster label value for dsn, and whether cluster
+// labels are configured at all. Data sources without an entry are labelled
+// with their fingerprint so that every series carries the same label set.
+func (e *Exporter) clusterLabel(dsn string) (string, bool) {
+	if len(e.dsnLabels) == 0 {
+		return "", false
+	}
+	fingerprint, err := parseFingerprint(dsn)
+	if err != nil {
+		return "", false
+	}
+	if cluster, ok := e.dsnLabels[fingerprint]; ok {
+		return cluster, true
+	}
+	return fingerprint, true
+}
+
 // WithTimeout configures the scrape timeout.
 func WithTimeout(timeout time.Duration) ExporterOpt {
 	return func(e *Exporter) {
@@ -528,7 +555,7 @@ func NewExporter(dsn []string, opts ...ExporterOpt) *Exporter {
 	}
 
 	e.setupInternalMetrics()
-	e.servers = NewServers(ServerWithLabels(e.constantLabels))
+	e.servers = NewServers(ServerWithLabels(e.constantLabels), ServerWithClusterLabels(e.dsnLabels))
 
 	return e
 }
@@ -57,6 +57,23 @@ func ServerWithLabels(labels prometheus.Labels) ServerOpt {
 	}
 }
 
+// ServerWithClusterLabels configures the cluster label from a map of server
+// fingerprints to cluster names. Servers without an entry use their
+// fingerprint. Nothing is added when clusters is empty.
+func ServerWithClusterLabels(clusters map[string]string) ServerOpt {
+	return func(s *Server) {
+		if len(clusters) == 0 {
+			return
+		}
+		fingerprint := s.labels[serverLabelName]
+		if cluster, ok := clusters[fingerprint]; ok {
+			s.labels[clusterLabelName] = cluster
+			return
+		}
+		s.labels[clusterLabelName] = fingerprint
+	}
+}
+
 // NewServer establishes a new connection using DSN.
 func NewServer(dsn string, opts ...ServerOpt) (*Server, error) {
 	fingerprint, err := parseFingerprint(dsn)
@@ -188,9 +205,10 @@ func (s *Servers) GetServer(dsn string) (*Server, error) {
 func (s *Servers) Close() {
 	s.m.Lock()
 	defer s.m.Unlock()
-	for _, server := range s.servers {
+	for dsn, server := range s.servers {
 		if err := server.Close(); err != nil {
 			logger.Error("Failed to close connection", "server", server, "err", err)
 		}
+		delete(s.servers, dsn)
 	}
 }
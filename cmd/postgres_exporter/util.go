@@ -22,6 +22,7 @@ import (
 	"time"
 
 	"github.com/lib/pq"
+	"github.com/prometheus-community/postgres_exporter/config"
 )
 
 // convert a string to the corresponding ColumnUsage
@@ -167,17 +168,11 @@ func parseFingerprint(url string) (string, error) {
 		dsn = url
 	}
 
-	pairs := strings.Split(dsn, " ")
-	kv := make(map[string]string, len(pairs))
-	for _, pair := range pairs {
-		splitted := strings.SplitN(pair, "=", 2)
-		if len(splitted) != 2 {
-			return "", fmt.Errorf("malformed dsn %q", dsn)
-		}
-		// Newer versions of pq.ParseURL quote values so trim them off if they exist
-		key := strings.Trim(splitted[0], "'\"")
-		value := strings.Trim(splitted[1], "'\"")
-		kv[key] = value
+	// Values may be quoted and contain spaces, e.g. the options added by
+	// --database.lock-timeout, so split with the config DSN parser.
+	kv, err := config.ParseKeyValue(dsn)
+	if err != nil || len(kv) == 0 {
+		return "", fmt.Errorf("malformed dsn %q", dsn)
 	}
 
 	var fingerprint string
@@ -0,0 +1,170 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/lib/pq"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	excludeApplicationNamesFlag *[]string = nil
+	activitySkipOnStandbyFlag   *bool     = nil
+	activityRoleLabelFlag       *bool     = nil
+)
+
+func init() {
+	excludeApplicationNamesFlag = kingpin.Flag(
+		"exclude-application-names",
+		"application_name of backends to leave out of activity-based collectors, in addition to the exporter's own backend. Can be repeated.").
+		Strings()
+	activitySkipOnStandbyFlag = kingpin.Flag(
+		"collector.activity.skip-on-standby",
+		"Skip activity-based collectors on a standby.").
+		Default("false").
+		Bool()
+	activityRoleLabelFlag = kingpin.Flag(
+		"collector.activity.role-label",
+		"Add a role=\"primary\" or role=\"standby\" label to the metrics of activity-based collectors.").
+		Default("false").
+		Bool()
+}
+
+// activitySkipOnStandby returns the configured standby behaviour, or false
+// when the flags have not been parsed.
+func activitySkipOnStandby() bool {
+	if activitySkipOnStandbyFlag == nil {
+		return false
+	}
+	return *activitySkipOnStandbyFlag
+}
+
+// activityRoleLabel returns whether the role label is enabled, or false when
+// the flags have not been parsed.
+func activityRoleLabel() bool {
+	if activityRoleLabelFlag == nil {
+		return false
+	}
+	return *activityRoleLabelFlag
+}
+
+const (
+	activityRolePrimary = "primary"
+	activityRoleStandby = "standby"
+)
+
+// activityRole returns the role label for activity-based collectors, or ""
+// when roleLabel is not set. A standby's pg_stat_activity only shows the
+// standby's own sessions, so its numbers are not comparable with the
+// primary's. When skipOnStandby is set and the server is in recovery, skip
+// is true and the collector should return without emitting anything. The
+// recovery state is only queried when needed, and then once per scrape.
+func activityRole(ctx context.Context, instance *Instance, skipOnStandby, roleLabel bool, logger *slog.Logger) (role string, skip bool, err error) {
+	if !skipOnStandby && !roleLabel {
+		return "", false, nil
+	}
+	inRecovery, err := instance.isInRecovery(ctx)
+	if err != nil {
+		return "", false, err
+	}
+	if inRecovery && skipOnStandby {
+		if logger != nil {
+			logger.Debug("Skipping activity-based collector on a standby")
+		}
+		return "", true, nil
+	}
+	if !roleLabel {
+		return "", false, nil
+	}
+	return serverRole(inRecovery), false, nil
+}
+
+func serverRole(inRecovery bool) string {
+	if inRecovery {
+		return activityRoleStandby
+	}
+	return activityRolePrimary
+}
+
+// roleLabelledDescs holds the role-labelled variant of every activityDesc,
+// so that a NaN placeholder can be given the role label.
+var roleLabelledDescs = map[*prometheus.Desc]bool{}
+
+// activityDesc describes a metric of an activity-based collector, with a
+// variant carrying the role label for use with --collector.activity.role-label.
+type activityDesc struct {
+	plain    *prometheus.Desc
+	withRole *prometheus.Desc
+}
+
+func newActivityDesc(fqName, help string, variableLabels []string) activityDesc {
+	d := activityDesc{
+		plain:    prometheus.NewDesc(fqName, help, variableLabels, nil),
+		withRole: prometheus.NewDesc(fqName, help, append(variableLabels[:len(variableLabels):len(variableLabels)], "role"), nil),
+	}
+	roleLabelledDescs[d.withRole] = true
+	return d
+}
+
+// desc returns the descriptor for metrics with the given role, as returned
+// by activityRole.
+func (d activityDesc) desc(role string) *prometheus.Desc {
+	if role == "" {
+		return d.plain
+	}
+	return d.withRole
+}
+
+// labelValues appends role, if any, to labelValues.
+func (d activityDesc) labelValues(role string, labelValues ...string) []string {
+	if role == "" {
+		return labelValues
+	}
+	return append(labelValues, role)
+}
+
+func (d activityDesc) mustNewConstMetric(role string, valueType prometheus.ValueType, value float64, labelValues ...string) prometheus.Metric {
+	return prometheus.MustNewConstMetric(d.desc(role), valueType, value, d.labelValues(role, labelValues...)...)
+}
+
+// excludeApplicationNames returns the configured application names, or nil
+// when the flags have not been parsed.
+func excludeApplicationNames() []string {
+	if excludeApplicationNamesFlag == nil {
+		return nil
+	}
+	return *excludeApplicationNamesFlag
+}
+
+// excludeSelfCondition returns a pg_stat_activity condition that excludes the
+// exporter's own backend and any backend whose application_name is in
+// applicationNames. Only the exporter's pid is excluded, not every backend
+// of the exporter's user or database, which would also hide application
+// sessions sharing those.
+func excludeSelfCondition(applicationNames []string) string {
+	condition := "pid <> pg_backend_pid()"
+	if len(applicationNames) == 0 {
+		return condition
+	}
+	quoted := make([]string, len(applicationNames))
+	for i, name := range applicationNames {
+		quoted[i] = pq.QuoteLiteral(name)
+	}
+	return condition + " AND COALESCE(application_name, '') NOT IN (" + strings.Join(quoted, ", ") + ")"
+}
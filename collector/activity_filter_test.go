@@ -0,0 +1,157 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package collector
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+)
+
+func TestExcludeSelfCondition(t *testing.T) {
+	tests := []struct {
+		name             string
+		applicationNames []string
+		want             string
+	}{
+		{
+			name: "own backend only",
+			want: "pid <> pg_backend_pid()",
+		},
+		{
+			name:             "with application names",
+			applicationNames: []string{"pgbouncer", "o'brien"},
+			want:             "pid <> pg_backend_pid() AND COALESCE(application_name, '') NOT IN ('pgbouncer', 'o''brien')",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := excludeSelfCondition(tt.applicationNames); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// expectActivityRole expects the recovery check made by activity-based
+// collectors.
+func expectActivityRole(mock sqlmock.Sqlmock, inRecovery bool) {
+	mock.ExpectQuery(sanitizeQuery("SELECT pg_is_in_recovery();")).
+		WillReturnRows(sqlmock.NewRows([]string{"pg_is_in_recovery"}).AddRow(inRecovery))
+}
+
+func TestActivityCollectorsOnStandby(t *testing.T) {
+	tests := []struct {
+		name          string
+		skipOnStandby bool
+		roleLabel     bool
+		expected      []MetricResult
+	}{
+		{
+			name: "unlabelled",
+			expected: []MetricResult{
+				{labels: labelMap{"datname": "orders"}, value: 4, metricType: dto.MetricType_GAUGE},
+				{labels: labelMap{}, value: 12.5, metricType: dto.MetricType_GAUGE},
+			},
+		},
+		{
+			name:      "labelled",
+			roleLabel: true,
+			expected: []MetricResult{
+				{labels: labelMap{"datname": "orders", "role": "standby"}, value: 4, metricType: dto.MetricType_GAUGE},
+				{labels: labelMap{"role": "standby"}, value: 12.5, metricType: dto.MetricType_GAUGE},
+			},
+		},
+		{
+			name:          "skipped",
+			skipOnStandby: true,
+			roleLabel:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("Error opening a stub db connection: %s", err)
+			}
+			defer db.Close()
+
+			inst := &Instance{db: db}
+			c := PGIdleConnectionsCollector{skipOnStandby: tt.skipOnStandby, roleLabel: tt.roleLabel}
+
+			if tt.skipOnStandby || tt.roleLabel {
+				expectActivityRole(mock, true)
+			}
+			if !tt.skipOnStandby {
+				rows := sqlmock.NewRows([]string{"datname", "connections", "oldest_seconds"}).
+					AddRow("orders", 4, 12.5)
+				mock.ExpectQuery(sanitizeQuery(c.query())).WillReturnRows(rows)
+			}
+
+			ch := make(chan prometheus.Metric)
+			go func() {
+				defer close(ch)
+				if err := c.Update(context.Background(), inst, ch); err != nil {
+					t.Errorf("Error calling PGIdleConnectionsCollector.Update: %s", err)
+				}
+			}()
+
+			var metrics []MetricResult
+			for m := range ch {
+				metrics = append(metrics, readMetric(m))
+			}
+			convey.Convey("Metrics comparison", t, func() {
+				convey.So(metrics, convey.ShouldResemble, tt.expected)
+			})
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled exceptions: %s", err)
+			}
+		})
+	}
+}
+
+func TestActivityRoleQueriedOncePerScrape(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error opening a stub db connection: %s", err)
+	}
+	defer db.Close()
+
+	inst := &Instance{db: db}
+	expectActivityRole(mock, false)
+	for i := 0; i < 3; i++ {
+		role, skip, err := activityRole(context.Background(), inst, true, true, nil)
+		if err != nil || skip || role != activityRolePrimary {
+			t.Fatalf("got role=%q skip=%v err=%v, want primary", role, skip, err)
+		}
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+
+	// A new scrape sets the instance up again and queries the role afresh.
+	inst.resetRecovery()
+	expectActivityRole(mock, true)
+	if role, _, err := activityRole(context.Background(), inst, false, true, nil); err != nil || role != activityRoleStandby {
+		t.Fatalf("got role=%q err=%v, want standby", role, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
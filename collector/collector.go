@@ -19,11 +19,15 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"math"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/alecthomas/kingpin/v2"
+	"github.com/lib/pq"
 	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
 )
 
 var (
@@ -32,6 +36,12 @@ var (
 	initiatedCollectors    = make(map[string]Collector)
 	collectorState         = make(map[string]*bool)
 	forcedCollectors       = map[string]bool{} // collectors which have been explicitly enabled or disabled
+
+	strictVersionGating = kingpin.Flag(
+		"collector.strict-version-gating",
+		"Fail collectors that cannot run on the server's PostgreSQL version instead of skipping them silently.").
+		Default("false").
+		Bool()
 )
 
 const (
@@ -56,12 +66,36 @@ var (
 		[]string{"collector"},
 		nil,
 	)
+	collectorLastErrorDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "collector", "last_error"),
+		"postgres_exporter: Error returned by a collector's last failed scrape (value is always 1).",
+		[]string{"collector", "error"},
+		nil,
+	)
+	collectorTimeoutDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "collector", "timeout"),
+		"postgres_exporter: Whether a collector was still running when the scrape deadline was reached (value is always 1).",
+		[]string{"collector"},
+		nil,
+	)
+	collectorEnabledDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "exporter", "collector_enabled"),
+		"postgres_exporter: Whether a collector is enabled after flag parsing.",
+		[]string{"collector"},
+		nil,
+	)
 )
 
 type Collector interface {
 	Update(ctx context.Context, instance *Instance, ch chan<- prometheus.Metric) error
 }
 
+// describer is implemented by collectors that can list the descriptors of the
+// metrics they emit. It is used to emit NaN placeholders when Update fails.
+type describer interface {
+	Describe(ch chan<- *prometheus.Desc)
+}
+
 type collectorConfig struct {
 	logger           *slog.Logger
 	excludeDatabases []string
@@ -89,14 +123,30 @@ func registerCollector(name string, isDefaultEnabled bool, createFunc func(colle
 
 // PostgresCollector implements the prometheus.Collector interface.
 type PostgresCollector struct {
-	Collectors      map[string]Collector
-	logger          *slog.Logger
-	scrapeTimeout   time.Duration
-	instanceFactory InstanceFactory
+	Collectors       map[string]Collector
+	logger           *slog.Logger
+	scrapeTimeout    time.Duration
+	scrapeDeadline   time.Duration
+	nanSeries        *seriesMemory
+	pingBeforeScrape bool
+	instanceFactory  InstanceFactory
 }
 
 type Option func(*PostgresCollector) error
 
+// WithNaNOnError configures failed collectors to emit their metrics as NaN
+// instead of leaving a gap. See updateWithNaNOnError for which series are
+// emitted.
+func WithNaNOnError(enabled bool) Option {
+	return func(p *PostgresCollector) error {
+		p.nanSeries = nil
+		if enabled {
+			p.nanSeries = newSeriesMemory()
+		}
+		return nil
+	}
+}
+
 // WithTimeout configures the scrape timeout.
 func WithTimeout(timeout time.Duration) Option {
 	return func(p *PostgresCollector) error {
@@ -105,6 +155,27 @@ func WithTimeout(timeout time.Duration) Option {
 	}
 }
 
+// WithScrapeDeadline configures an upper bound on the whole scrape. Collectors
+// still running when it is reached are cancelled and reported as timed out,
+// while the metrics already collected are returned.
+func WithScrapeDeadline(deadline time.Duration) Option {
+	return func(p *PostgresCollector) error {
+		p.scrapeDeadline = deadline
+		return nil
+	}
+}
+
+// WithPingBeforeScrape configures the collector to check the connection
+// before running collectors, so a dead connection skips the scrape once up
+// front instead of failing every collector in turn. Reconnecting is left to
+// the instance factory and pg_up to the main exporter.
+func WithPingBeforeScrape(enabled bool) Option {
+	return func(p *PostgresCollector) error {
+		p.pingBeforeScrape = enabled
+		return nil
+	}
+}
+
 // NewPostgresCollector creates a new PostgresCollector.
 func NewPostgresCollector(logger *slog.Logger, excludeDatabases []string, factory InstanceFactory, filters []string, options ...Option) (*PostgresCollector, error) {
 	p := &PostgresCollector{
@@ -161,17 +232,32 @@ func NewPostgresCollector(logger *slog.Logger, excludeDatabases []string, factor
 func (p PostgresCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- scrapeDurationDesc
 	ch <- scrapeSuccessDesc
+	ch <- collectorLastErrorDesc
+	ch <- collectorTimeoutDesc
+	ch <- collectorEnabledDesc
 }
 
 // Collect implements the prometheus.Collector interface.
 func (p PostgresCollector) Collect(ch chan<- prometheus.Metric) {
-	var ctx context.Context
+	deadlineCtx := context.Background()
+	if p.scrapeDeadline > 0 {
+		var cancel context.CancelFunc
+		deadlineCtx, cancel = context.WithTimeout(deadlineCtx, p.scrapeDeadline)
+		defer cancel()
+	}
+	ctx := deadlineCtx
 	if p.scrapeTimeout > 0 {
 		var cancel context.CancelFunc
-		ctx, cancel = context.WithTimeout(context.Background(), p.scrapeTimeout)
+		ctx, cancel = context.WithTimeout(ctx, p.scrapeTimeout)
 		defer cancel()
-	} else {
-		ctx = context.Background()
+	}
+
+	for name, enabled := range collectorState {
+		value := 0.0
+		if *enabled {
+			value = 1
+		}
+		ch <- prometheus.MustNewConstMetric(collectorEnabledDesc, prometheus.GaugeValue, value, name)
 	}
 
 	// Use the factory to get an instance
@@ -180,23 +266,168 @@ func (p PostgresCollector) Collect(ch chan<- prometheus.Metric) {
 		p.logger.Error("Error creating instance", "err", err)
 		return
 	}
+	if p.pingBeforeScrape {
+		if err := inst.ping(ctx); err != nil {
+			p.logger.Error("Database connection is not available, skipping collectors", "err", err)
+			inst.Close()
+			return
+		}
+	}
+	if p.scrapeDeadline > 0 {
+		p.collectUntilDeadline(ctx, deadlineCtx.Done(), inst, ch)
+		return
+	}
 	defer inst.Close() // Always safe - closeDB flag determines if connection is actually closed
 
 	wg := sync.WaitGroup{}
 	wg.Add(len(p.Collectors))
 	for name, c := range p.Collectors {
 		go func(name string, c Collector) {
-			execute(ctx, name, c, inst, ch, p.logger)
+			execute(ctx, name, c, inst, ch, p.logger, p.nanSeries)
 			wg.Done()
 		}(name, c)
 	}
 	wg.Wait()
 }
 
-func execute(ctx context.Context, name string, c Collector, instance *Instance, ch chan<- prometheus.Metric, logger *slog.Logger) {
+// collectUntilDeadline runs the collectors like Collect, but stops forwarding
+// their metrics once deadline is closed. Collectors that have not finished by
+// then are reported as timed out; their late metrics are discarded, and inst
+// is closed only once every collector has returned.
+func (p PostgresCollector) collectUntilDeadline(ctx context.Context, deadline <-chan struct{}, inst *Instance, ch chan<- prometheus.Metric) {
+	metrics := make(chan collectorMetric)
+	finished := make(chan string)
+	states := make(map[string]*collectorRunState, len(p.Collectors))
+	pending := make(map[string]bool, len(p.Collectors))
+	for name := range p.Collectors {
+		states[name] = &collectorRunState{}
+		pending[name] = true
+	}
+	for name, c := range p.Collectors {
+		go func(name string, c Collector, state *collectorRunState) {
+			out := make(chan prometheus.Metric)
+			forwarded := make(chan struct{})
+			go func() {
+				for m := range out {
+					metrics <- collectorMetric{name: name, metric: m}
+				}
+				close(forwarded)
+			}()
+			begin := time.Now()
+			err := update(ctx, name, c, inst, out, p.nanSeries)
+			if state.complete() {
+				reportResult(name, time.Since(begin), err, out, p.logger)
+			}
+			close(out)
+			<-forwarded
+			finished <- name
+		}(name, c, states[name])
+	}
+
+	// abandoned counts timed-out collectors that have not returned yet.
+	timedOut := make(map[string]bool)
+	abandoned := 0
+	for len(pending) > 0 {
+		select {
+		case m := <-metrics:
+			if !timedOut[m.name] {
+				ch <- m.metric
+			}
+		case name := <-finished:
+			if timedOut[name] {
+				abandoned--
+			}
+			delete(pending, name)
+		case <-deadline:
+			deadline = nil
+			for name := range pending {
+				// A collector that completed just before the deadline has
+				// already reported its own result; wait for it instead.
+				if !states[name].timeOut() {
+					continue
+				}
+				p.logger.Error("collector timed out", "name", name)
+				ch <- prometheus.MustNewConstMetric(collectorTimeoutDesc, prometheus.GaugeValue, 1, name)
+				ch <- prometheus.MustNewConstMetric(scrapeSuccessDesc, prometheus.GaugeValue, 0, name)
+				timedOut[name] = true
+				abandoned++
+				delete(pending, name)
+			}
+		}
+	}
+	if abandoned == 0 {
+		inst.Close()
+		return
+	}
+	go func() {
+		for abandoned > 0 {
+			select {
+			case <-metrics:
+			case <-finished:
+				abandoned--
+			}
+		}
+		inst.Close()
+	}()
+}
+
+// collectorMetric is a metric tagged with the collector that produced it.
+type collectorMetric struct {
+	name   string
+	metric prometheus.Metric
+}
+
+// collectorRunState records whether a collector running under a scrape
+// deadline completed or timed out first, so that exactly one of the two
+// reports its pg_scrape_collector_success series.
+type collectorRunState struct {
+	mu        sync.Mutex
+	completed bool
+	timedOut  bool
+}
+
+// complete marks the collector as completed and reports whether it may
+// report its own result, i.e. it has not already been timed out.
+func (s *collectorRunState) complete() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.timedOut {
+		return false
+	}
+	s.completed = true
+	return true
+}
+
+// timeOut marks the collector as timed out and reports whether it had not
+// completed yet.
+func (s *collectorRunState) timeOut() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.completed {
+		return false
+	}
+	s.timedOut = true
+	return true
+}
+
+func execute(ctx context.Context, name string, c Collector, instance *Instance, ch chan<- prometheus.Metric, logger *slog.Logger, nanSeries *seriesMemory) {
 	begin := time.Now()
-	err := c.Update(ctx, instance, ch)
-	duration := time.Since(begin)
+	err := update(ctx, name, c, instance, ch, nanSeries)
+	reportResult(name, time.Since(begin), err, ch, logger)
+}
+
+// update runs c.Update, emitting NaN placeholders on failure if nanSeries
+// is set.
+func update(ctx context.Context, name string, c Collector, instance *Instance, ch chan<- prometheus.Metric, nanSeries *seriesMemory) error {
+	if nanSeries != nil {
+		return updateWithNaNOnError(ctx, name, c, instance, ch, nanSeries)
+	}
+	return c.Update(ctx, instance, ch)
+}
+
+// reportResult emits the scrape duration and success of a collector run, and
+// its error if it failed.
+func reportResult(name string, duration time.Duration, err error, ch chan<- prometheus.Metric, logger *slog.Logger) {
 	var success float64
 
 	if err != nil {
@@ -204,6 +435,7 @@ func execute(ctx context.Context, name string, c Collector, instance *Instance,
 			logger.Debug("collector returned no data", "name", name, "duration_seconds", duration.Seconds(), "err", err)
 		} else {
 			logger.Error("collector failed", "name", name, "duration_seconds", duration.Seconds(), "err", err)
+			ch <- prometheus.MustNewConstMetric(collectorLastErrorDesc, prometheus.GaugeValue, 1, name, errorLabel(err))
 		}
 		success = 0
 	} else {
@@ -214,6 +446,178 @@ func execute(ctx context.Context, name string, c Collector, instance *Instance,
 	ch <- prometheus.MustNewConstMetric(scrapeSuccessDesc, prometheus.GaugeValue, success, name)
 }
 
+// maxErrorLabelLength caps the error label of pg_collector_last_error so that
+// long or highly variable messages do not create unbounded series.
+const maxErrorLabelLength = 128
+
+// errorLabel returns err's message made safe for use as a label value: valid
+// UTF-8 on a single line, truncated to maxErrorLabelLength runes.
+func errorLabel(err error) string {
+	msg := strings.ToValidUTF8(err.Error(), "?")
+	msg = strings.Join(strings.Fields(msg), " ")
+	if r := []rune(msg); len(r) > maxErrorLabelLength {
+		msg = string(r[:maxErrorLabelLength-3]) + "..."
+	}
+	return msg
+}
+
+// updateWithNaNOnError runs c.Update and, if it fails, emits NaN for every
+// series the collector emitted on its last successful run but did not send
+// before failing, so that works for every collector and for labelled series.
+// Collectors that implement Describe additionally get NaN for their
+// unlabelled descriptors, which covers a collector that has never succeeded.
+func updateWithNaNOnError(ctx context.Context, name string, c Collector, instance *Instance, ch chan<- prometheus.Metric, memory *seriesMemory) error {
+	var emitted []nanSeries
+	sent := make(map[seriesKey]bool)
+	tracked := make(chan prometheus.Metric)
+	done := make(chan struct{})
+	go func() {
+		for m := range tracked {
+			if s, ok := newNaNSeries(m); ok {
+				emitted = append(emitted, s)
+				sent[s.key()] = true
+			}
+			ch <- m
+		}
+		close(done)
+	}()
+	err := c.Update(ctx, instance, tracked)
+	close(tracked)
+	<-done
+
+	if err == nil {
+		memory.set(name, emitted)
+		return nil
+	}
+	if IsNoDataError(err) {
+		return err
+	}
+
+	for _, s := range memory.get(name) {
+		if sent[s.key()] {
+			continue
+		}
+		sent[s.key()] = true
+		ch <- s
+	}
+
+	d, ok := c.(describer)
+	if !ok {
+		return err
+	}
+	descs := make(chan *prometheus.Desc)
+	go func() {
+		d.Describe(descs)
+		close(descs)
+	}()
+	for desc := range descs {
+		if sent[seriesKey{desc: desc}] {
+			continue
+		}
+		// Descriptors with variable labels fail here, as there are no
+		// label values to attach to the placeholder, except for the role
+		// label of activity-based collectors when the role is known.
+		m, merr := prometheus.NewConstMetric(desc, prometheus.GaugeValue, math.NaN())
+		if merr != nil && roleLabelledDescs[desc] && instance != nil {
+			if role, ok := instance.cachedRole(); ok {
+				m, merr = prometheus.NewConstMetric(desc, prometheus.GaugeValue, math.NaN(), role)
+			}
+		}
+		if merr != nil {
+			continue
+		}
+		ch <- m
+	}
+	return err
+}
+
+// seriesMemory remembers, per collector, the series emitted on the last
+// successful run.
+type seriesMemory struct {
+	mu     sync.Mutex
+	series map[string][]nanSeries
+}
+
+func newSeriesMemory() *seriesMemory {
+	return &seriesMemory{series: make(map[string][]nanSeries)}
+}
+
+func (m *seriesMemory) set(name string, series []nanSeries) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.series[name] = series
+}
+
+func (m *seriesMemory) get(name string) []nanSeries {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.series[name]
+}
+
+// seriesKey identifies a series by its descriptor and label pairs.
+type seriesKey struct {
+	desc   *prometheus.Desc
+	labels string
+}
+
+// nanSeries is a prometheus.Metric that repeats a previously emitted series,
+// keeping its descriptor, labels and type, with a NaN value.
+type nanSeries struct {
+	desc       *prometheus.Desc
+	labels     []*dto.LabelPair
+	metricType dto.MetricType
+}
+
+// newNaNSeries records the series of m. Only counters, gauges and untyped
+// metrics are recorded; a NaN histogram or summary has no useful meaning.
+func newNaNSeries(m prometheus.Metric) (nanSeries, bool) {
+	var out dto.Metric
+	if err := m.Write(&out); err != nil {
+		return nanSeries{}, false
+	}
+	s := nanSeries{desc: m.Desc(), labels: out.GetLabel()}
+	switch {
+	case out.Counter != nil:
+		s.metricType = dto.MetricType_COUNTER
+	case out.Gauge != nil:
+		s.metricType = dto.MetricType_GAUGE
+	case out.Untyped != nil:
+		s.metricType = dto.MetricType_UNTYPED
+	default:
+		return nanSeries{}, false
+	}
+	return s, true
+}
+
+func (s nanSeries) key() seriesKey {
+	var b strings.Builder
+	for _, l := range s.labels {
+		b.WriteString(l.GetName())
+		b.WriteByte('=')
+		b.WriteString(l.GetValue())
+		b.WriteByte(0)
+	}
+	return seriesKey{desc: s.desc, labels: b.String()}
+}
+
+func (s nanSeries) Desc() *prometheus.Desc {
+	return s.desc
+}
+
+func (s nanSeries) Write(out *dto.Metric) error {
+	nan := math.NaN()
+	out.Label = s.labels
+	switch s.metricType {
+	case dto.MetricType_COUNTER:
+		out.Counter = &dto.Counter{Value: &nan}
+	case dto.MetricType_GAUGE:
+		out.Gauge = &dto.Gauge{Value: &nan}
+	default:
+		out.Untyped = &dto.Untyped{Value: &nan}
+	}
+	return nil
+}
+
 // collectorFlagAction generates a new action function for the given collector
 // to track whether it has been explicitly enabled or disabled from the command line.
 // A new action function is needed for each collector flag because the ParseContext
@@ -233,6 +637,30 @@ func IsNoDataError(err error) bool {
 	return err == ErrNoData
 }
 
+// ErrUnsupportedVersion is returned by collectors that cannot run on the
+// server's PostgreSQL version when --collector.strict-version-gating is set.
+var ErrUnsupportedVersion = errors.New("collector is not supported on this PostgreSQL version")
+
+// skipUnsupportedVersion is returned from Update by collectors that cannot run
+// on the server's version. It logs reason and returns nil so the collector is
+// skipped, or returns ErrUnsupportedVersion under strict version gating so
+// the collector is reported as failed.
+func skipUnsupportedVersion(logger *slog.Logger, reason string) error {
+	if *strictVersionGating {
+		return fmt.Errorf("%w: %s", ErrUnsupportedVersion, reason)
+	}
+	if logger != nil {
+		logger.Debug(reason)
+	}
+	return nil
+}
+
+// isInsufficientPrivilege reports whether err is a PostgreSQL permission denied error.
+func isInsufficientPrivilege(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "42501"
+}
+
 func Int32(m sql.NullInt32) float64 {
 	mM := 0.0
 	if m.Valid {
@@ -13,10 +13,17 @@
 package collector
 
 import (
+	"context"
+	"errors"
+	"math"
 	"strings"
+	"testing"
+	"time"
 
+	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/prometheus/client_golang/prometheus"
 	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/promslog"
 )
 
 type labelMap map[string]string
@@ -56,7 +63,437 @@ func sanitizeQuery(q string) string {
 	q = strings.ReplaceAll(q, "{", "\\{")
 	q = strings.ReplaceAll(q, "}", "\\}")
 	q = strings.ReplaceAll(q, "*", "\\*")
+	q = strings.ReplaceAll(q, "+", "\\+")
 	q = strings.ReplaceAll(q, "^", "\\^")
 	q = strings.ReplaceAll(q, "$", "\\$")
 	return q
 }
+
+func TestPostgresCollectorEnabledMetric(t *testing.T) {
+	for name, want := range map[string]bool{
+		buffercacheSubsystem:    false,
+		invalidIndexesSubsystem: true,
+	} {
+		enabled := collectorState[name]
+		orig := *enabled
+		defer func() { *enabled = orig }()
+		*enabled = want
+	}
+
+	p := PostgresCollector{
+		logger: promslog.NewNopLogger(),
+		instanceFactory: func() (*Instance, error) {
+			return nil, errors.New("no database")
+		},
+	}
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		defer close(ch)
+		p.Collect(ch)
+	}()
+
+	got := make(map[string]float64)
+	for m := range ch {
+		if m.Desc() != collectorEnabledDesc {
+			continue
+		}
+		r := readMetric(m)
+		got[r.labels["collector"]] = r.value
+	}
+
+	if len(got) != len(collectorState) {
+		t.Errorf("expected %d collector_enabled series, got %d", len(collectorState), len(got))
+	}
+	if v := got[buffercacheSubsystem]; v != 0 {
+		t.Errorf("expected disabled collector %q to report 0, got %v", buffercacheSubsystem, v)
+	}
+	if v := got[invalidIndexesSubsystem]; v != 1 {
+		t.Errorf("expected enabled collector %q to report 1, got %v", invalidIndexesSubsystem, v)
+	}
+}
+
+var (
+	failingCollectorFirstDesc    = prometheus.NewDesc("pg_failing_first", "First metric", nil, nil)
+	failingCollectorSecondDesc   = prometheus.NewDesc("pg_failing_second", "Second metric", nil, nil)
+	failingCollectorLabelledDesc = prometheus.NewDesc("pg_failing_labelled", "Labelled metric", []string{"datname"}, nil)
+)
+
+// failingCollector sends its first metric and then fails.
+type failingCollector struct{}
+
+func (failingCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- failingCollectorFirstDesc
+	ch <- failingCollectorSecondDesc
+	ch <- failingCollectorLabelledDesc
+}
+
+func (failingCollector) Update(ctx context.Context, instance *Instance, ch chan<- prometheus.Metric) error {
+	ch <- prometheus.MustNewConstMetric(failingCollectorFirstDesc, prometheus.GaugeValue, 1)
+	return errors.New("forced failure")
+}
+
+func TestExecuteNaNOnError(t *testing.T) {
+	for _, nanOnError := range []bool{false, true} {
+		ch := make(chan prometheus.Metric)
+		go func() {
+			defer close(ch)
+			var nanSeries *seriesMemory
+			if nanOnError {
+				nanSeries = newSeriesMemory()
+			}
+			execute(context.Background(), "failing", failingCollector{}, &Instance{}, ch, promslog.NewNopLogger(), nanSeries)
+		}()
+
+		got := make(map[string][]float64)
+		for m := range ch {
+			name := m.Desc().String()
+			got[name] = append(got[name], readMetric(m).value)
+		}
+
+		first := got[failingCollectorFirstDesc.String()]
+		if len(first) != 1 || first[0] != 1 {
+			t.Errorf("nanOnError=%v: expected the sent metric once with value 1, got %v", nanOnError, first)
+		}
+		second := got[failingCollectorSecondDesc.String()]
+		if nanOnError {
+			if len(second) != 1 || !math.IsNaN(second[0]) {
+				t.Errorf("expected a NaN series for the unsent metric, got %v", second)
+			}
+		} else if len(second) != 0 {
+			t.Errorf("expected no series for the unsent metric, got %v", second)
+		}
+		if labelled := got[failingCollectorLabelledDesc.String()]; len(labelled) != 0 {
+			t.Errorf("nanOnError=%v: expected no series for the labelled metric, got %v", nanOnError, labelled)
+		}
+		if success := got[scrapeSuccessDesc.String()]; len(success) != 1 || success[0] != 0 {
+			t.Errorf("nanOnError=%v: expected collector_success 0, got %v", nanOnError, success)
+		}
+	}
+}
+
+var flakyCollectorDesc = prometheus.NewDesc("pg_flaky_total", "Labelled counter", []string{"datname"}, nil)
+
+// flakyCollector has no Describe method. It emits one counter per database
+// while failing is unset, and only the first of them before failing.
+type flakyCollector struct {
+	failing *bool
+}
+
+func (c flakyCollector) Update(ctx context.Context, instance *Instance, ch chan<- prometheus.Metric) error {
+	ch <- prometheus.MustNewConstMetric(flakyCollectorDesc, prometheus.CounterValue, 1, "orders")
+	if *c.failing {
+		return errors.New("forced failure")
+	}
+	ch <- prometheus.MustNewConstMetric(flakyCollectorDesc, prometheus.CounterValue, 2, "users")
+	return nil
+}
+
+func TestExecuteNaNOnErrorWithoutDescribe(t *testing.T) {
+	failing := false
+	c := flakyCollector{failing: &failing}
+	nanSeries := newSeriesMemory()
+	run := func() []MetricResult {
+		ch := make(chan prometheus.Metric)
+		go func() {
+			defer close(ch)
+			execute(context.Background(), "flaky", c, &Instance{}, ch, promslog.NewNopLogger(), nanSeries)
+		}()
+		var got []MetricResult
+		for m := range ch {
+			if m.Desc() == flakyCollectorDesc {
+				got = append(got, readMetric(m))
+			}
+		}
+		return got
+	}
+
+	if got := run(); len(got) != 2 {
+		t.Fatalf("expected two series on success, got %v", got)
+	}
+	failing = true
+	got := run()
+	if len(got) != 2 {
+		t.Fatalf("expected the sent series and one NaN placeholder on failure, got %v", got)
+	}
+	if got[0].labels["datname"] != "orders" || got[0].value != 1 {
+		t.Errorf("expected the sent series unchanged, got %v", got[0])
+	}
+	if got[1].labels["datname"] != "users" || !math.IsNaN(got[1].value) || got[1].metricType != dto.MetricType_COUNTER {
+		t.Errorf("expected a NaN counter for datname=users, got %v", got[1])
+	}
+}
+
+func TestExecuteNaNOnErrorWithRoleLabel(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error opening a stub db connection: %s", err)
+	}
+	defer db.Close()
+
+	c := PGLongRunningTransactionsCollector{roleLabel: true}
+	expectActivityRole(mock, false)
+	mock.ExpectQuery(sanitizeQuery(c.query())).WillReturnError(errors.New("forced failure"))
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		defer close(ch)
+		execute(context.Background(), "long_running_transactions", c, &Instance{db: db}, ch, promslog.NewNopLogger(), newSeriesMemory())
+	}()
+	var got []MetricResult
+	for m := range ch {
+		if roleLabelledDescs[m.Desc()] {
+			got = append(got, readMetric(m))
+		}
+	}
+	if len(got) != 2 {
+		t.Fatalf("expected a NaN placeholder for both role-labelled metrics, got %v", got)
+	}
+	for _, m := range got {
+		if m.labels["role"] != activityRolePrimary || !math.IsNaN(m.value) {
+			t.Errorf("expected NaN with role=primary, got %v", m)
+		}
+	}
+}
+
+// instanceRecorder records the instance each Update call receives.
+type instanceRecorder struct {
+	instances chan *Instance
+}
+
+func (r instanceRecorder) Update(ctx context.Context, instance *Instance, ch chan<- prometheus.Metric) error {
+	r.instances <- instance
+	return nil
+}
+
+func TestPostgresCollectorPingBeforeScrape(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+	if err != nil {
+		t.Fatalf("Error opening a stub db connection: %s", err)
+	}
+	defer db.Close()
+	mock.ExpectPing()
+
+	inst := &Instance{db: db}
+	recorder := instanceRecorder{instances: make(chan *Instance, 1)}
+
+	p := PostgresCollector{
+		Collectors:       map[string]Collector{"recorder": recorder},
+		logger:           promslog.NewNopLogger(),
+		pingBeforeScrape: true,
+		instanceFactory: func() (*Instance, error) {
+			return inst, nil
+		},
+	}
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		defer close(ch)
+		p.Collect(ch)
+	}()
+	for range ch {
+	}
+
+	if got := <-recorder.instances; got != inst {
+		t.Errorf("expected collectors to run on the pinged instance")
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
+
+func TestPostgresCollectorPingBeforeScrapeDown(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+	if err != nil {
+		t.Fatalf("Error opening a stub db connection: %s", err)
+	}
+	defer db.Close()
+	mock.ExpectPing().WillReturnError(errors.New("connection refused"))
+
+	inst := &Instance{db: db}
+	recorder := instanceRecorder{instances: make(chan *Instance, 1)}
+
+	p := PostgresCollector{
+		Collectors:       map[string]Collector{"recorder": recorder},
+		logger:           promslog.NewNopLogger(),
+		pingBeforeScrape: true,
+		instanceFactory: func() (*Instance, error) {
+			return inst, nil
+		},
+	}
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		defer close(ch)
+		p.Collect(ch)
+	}()
+	for m := range ch {
+		if m.Desc() == scrapeSuccessDesc {
+			t.Errorf("expected no collectors to run")
+		}
+	}
+
+	if len(recorder.instances) != 0 {
+		t.Errorf("expected no collectors to run")
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
+
+// toggleCollector fails while failing is set and succeeds otherwise.
+type toggleCollector struct {
+	failing bool
+}
+
+func (c toggleCollector) Update(ctx context.Context, instance *Instance, ch chan<- prometheus.Metric) error {
+	if c.failing {
+		return errors.New("pq: permission denied for view pg_stat_replication\nDETAIL: " + strings.Repeat("x", 200))
+	}
+	return nil
+}
+
+func TestExecuteLastError(t *testing.T) {
+	run := func(c Collector) map[string]string {
+		ch := make(chan prometheus.Metric)
+		go func() {
+			defer close(ch)
+			execute(context.Background(), "toggle", c, &Instance{}, ch, promslog.NewNopLogger(), nil)
+		}()
+		var lastError map[string]string
+		for m := range ch {
+			if m.Desc() == collectorLastErrorDesc {
+				lastError = readMetric(m).labels
+			}
+		}
+		return lastError
+	}
+
+	labels := run(toggleCollector{failing: true})
+	if labels == nil {
+		t.Fatal("expected pg_collector_last_error on failure")
+	}
+	if labels["collector"] != "toggle" {
+		t.Errorf("expected collector label %q, got %q", "toggle", labels["collector"])
+	}
+	if got := labels["error"]; !strings.HasPrefix(got, "pq: permission denied for view pg_stat_replication DETAIL: ") {
+		t.Errorf("expected a single-line error label, got %q", got)
+	}
+	if got := len([]rune(labels["error"])); got != maxErrorLabelLength {
+		t.Errorf("expected error label truncated to %d runes, got %d", maxErrorLabelLength, got)
+	}
+
+	if labels := run(toggleCollector{failing: false}); labels != nil {
+		t.Errorf("expected no pg_collector_last_error after success, got %v", labels)
+	}
+}
+
+// blockingCollector waits for its context to be cancelled, signals that on
+// cancelled, and then does not return until release is closed, like a driver
+// that is slow to abort a query.
+type blockingCollector struct {
+	cancelled chan struct{}
+	release   chan struct{}
+}
+
+func (c blockingCollector) Update(ctx context.Context, instance *Instance, ch chan<- prometheus.Metric) error {
+	<-ctx.Done()
+	close(c.cancelled)
+	<-c.release
+	ch <- prometheus.MustNewConstMetric(failingCollectorFirstDesc, prometheus.GaugeValue, 1)
+	return ctx.Err()
+}
+
+// constantCollector emits a single metric.
+type constantCollector struct{}
+
+func (constantCollector) Update(ctx context.Context, instance *Instance, ch chan<- prometheus.Metric) error {
+	ch <- prometheus.MustNewConstMetric(failingCollectorSecondDesc, prometheus.GaugeValue, 1)
+	return nil
+}
+
+func TestPostgresCollectorScrapeDeadline(t *testing.T) {
+	slow := blockingCollector{cancelled: make(chan struct{}), release: make(chan struct{})}
+	defer close(slow.release)
+
+	p := PostgresCollector{
+		Collectors:     map[string]Collector{"slow": slow, "fast": constantCollector{}},
+		logger:         promslog.NewNopLogger(),
+		scrapeDeadline: 50 * time.Millisecond,
+		instanceFactory: func() (*Instance, error) {
+			return &Instance{}, nil
+		},
+	}
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		defer close(ch)
+		p.Collect(ch)
+	}()
+
+	var fastMetric, slowMetric bool
+	timedOut := make(map[string]bool)
+	success := make(map[string]float64)
+	for m := range ch {
+		switch m.Desc() {
+		case failingCollectorFirstDesc:
+			slowMetric = true
+		case failingCollectorSecondDesc:
+			fastMetric = true
+		case collectorTimeoutDesc:
+			timedOut[readMetric(m).labels["collector"]] = true
+		case scrapeSuccessDesc:
+			r := readMetric(m)
+			success[r.labels["collector"]] = r.value
+		}
+	}
+
+	select {
+	case <-slow.cancelled:
+	case <-time.After(time.Second):
+		t.Error("expected the slow collector's context to be cancelled")
+	}
+	if !fastMetric {
+		t.Error("expected the fast collector's metric to be returned")
+	}
+	if slowMetric {
+		t.Error("expected the slow collector's late metric to be discarded")
+	}
+	if !timedOut["slow"] || timedOut["fast"] {
+		t.Errorf("expected only the slow collector to time out, got %v", timedOut)
+	}
+	if success["slow"] != 0 || success["fast"] != 1 {
+		t.Errorf("expected collector_success 0 for slow and 1 for fast, got %v", success)
+	}
+}
+
+func TestPostgresCollectorScrapeDeadlineRace(t *testing.T) {
+	// The deadline fires right after the collector has reported its own
+	// success, before collectUntilDeadline has seen it finish.
+	for i := 0; i < 200; i++ {
+		deadline := make(chan struct{})
+		p := PostgresCollector{
+			Collectors: map[string]Collector{"edge": constantCollector{}},
+			logger:     promslog.NewNopLogger(),
+		}
+
+		ch := make(chan prometheus.Metric)
+		go func() {
+			defer close(ch)
+			p.collectUntilDeadline(context.Background(), deadline, &Instance{}, ch)
+		}()
+
+		successes := 0
+		for m := range ch {
+			if m.Desc() == scrapeSuccessDesc {
+				successes++
+				if successes == 1 {
+					close(deadline)
+				}
+			}
+		}
+		if successes != 1 {
+			t.Fatalf("iteration %d: expected exactly one pg_scrape_collector_success series, got %d", i, successes)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/version"
+)
+
+const exporterBuildInfoSubsystem = "exporter_build_info"
+
+func init() {
+	registerCollector(exporterBuildInfoSubsystem, defaultDisabled, NewExporterBuildInfoCollector)
+}
+
+// ExporterBuildInfoCollector reports the exporter's own build, as injected
+// through ldflags into github.com/prometheus/common/version. It does not
+// query the database; see PostgresBinariesCollector for server build times.
+type ExporterBuildInfoCollector struct {
+	log *slog.Logger
+}
+
+func NewExporterBuildInfoCollector(config collectorConfig) (Collector, error) {
+	return &ExporterBuildInfoCollector{log: config.logger}, nil
+}
+
+var exporterBuildInfoDesc = prometheus.NewDesc(
+	prometheus.BuildFQName(namespace, "exporter", "build_info"),
+	"postgres_exporter build information (value is always 1)",
+	[]string{"version", "revision", "branch", "goversion"}, nil,
+)
+
+func (c ExporterBuildInfoCollector) Update(ctx context.Context, instance *Instance, ch chan<- prometheus.Metric) error {
+	ch <- prometheus.MustNewConstMetric(
+		exporterBuildInfoDesc,
+		prometheus.GaugeValue, 1,
+		version.Version, version.GetRevision(), version.Branch, version.GoVersion,
+	)
+	return nil
+}
@@ -0,0 +1,55 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package collector
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/version"
+	"github.com/smartystreets/goconvey/convey"
+)
+
+func TestExporterBuildInfoCollector(t *testing.T) {
+	origVersion, origRevision, origBranch, origGoVersion := version.Version, version.Revision, version.Branch, version.GoVersion
+	defer func() {
+		version.Version, version.Revision, version.Branch, version.GoVersion = origVersion, origRevision, origBranch, origGoVersion
+	}()
+	version.Version = "0.17.1"
+	version.Revision = "1e6a3c8"
+	version.Branch = "main"
+	version.GoVersion = "go1.24.1"
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		defer close(ch)
+		c := ExporterBuildInfoCollector{}
+
+		if err := c.Update(context.Background(), &Instance{}, ch); err != nil {
+			t.Errorf("Error calling ExporterBuildInfoCollector.Update: %s", err)
+		}
+	}()
+
+	expected := []MetricResult{
+		{labels: labelMap{"version": "0.17.1", "revision": "1e6a3c8", "branch": "main", "goversion": "go1.24.1"}, value: 1, metricType: dto.MetricType_GAUGE},
+	}
+
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range expected {
+			m := readMetric(<-ch)
+			convey.So(expect, convey.ShouldResemble, m)
+		}
+	})
+}
@@ -14,9 +14,11 @@
 package collector
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"regexp"
+	"sync"
 
 	"github.com/blang/semver/v4"
 )
@@ -25,17 +27,44 @@ type Instance struct {
 	dsn     string
 	db      *sql.DB
 	version semver.Version
-	closeDB bool // whether we should close the connection on Close()
+	// versionString is the raw banner the version was parsed from, such as
+	// the full result of SELECT version(). It is empty with a version
+	// override.
+	versionString string
+	closeDB       bool // whether we should close the connection on Close()
+
+	// versionOverride, when set, is used instead of querying the server version.
+	versionOverride *semver.Version
+
+	// inRecovery caches pg_is_in_recovery() for the scrape; it is reset
+	// whenever the instance is set up.
+	recoveryMu sync.Mutex
+	inRecovery *bool
 }
 
-func NewInstance(dsn string) (*Instance, error) {
+// InstanceOpt configures an Instance.
+type InstanceOpt func(*Instance)
+
+// WithVersionOverride skips server version detection and uses v instead.
+// This is intended for poolers and proxies that answer SELECT version()
+// with their own banner rather than the backend's.
+func WithVersionOverride(v semver.Version) InstanceOpt {
+	return func(i *Instance) {
+		i.versionOverride = &v
+	}
+}
+
+func NewInstance(dsn string, opts ...InstanceOpt) (*Instance, error) {
 	i := &Instance{
 		dsn: dsn,
 	}
+	for _, opt := range opts {
+		opt(i)
+	}
 
 	// "Create" a database handle to verify the DSN provided is valid.
 	// Open is not guaranteed to create a connection.
-	db, err := sql.Open("postgres", dsn)
+	db, err := sql.Open("postgres", i.dsn)
 	if err != nil {
 		return nil, err
 	}
@@ -47,7 +76,8 @@ func NewInstance(dsn string) (*Instance, error) {
 // copy returns a copy of the instance.
 func (i *Instance) copy() *Instance {
 	return &Instance{
-		dsn: i.dsn,
+		dsn:             i.dsn,
+		versionOverride: i.versionOverride,
 	}
 }
 
@@ -60,26 +90,34 @@ func (i *Instance) setup() error {
 	db.SetMaxIdleConns(1)
 	i.db = db
 	i.closeDB = true // we created this connection, so we should close it
+	i.resetRecovery()
 
-	version, err := queryVersion(i.db)
-	if err != nil {
-		return fmt.Errorf("error querying postgresql version: %w", err)
-	} else {
-		i.version = version
-	}
-	return nil
+	return i.setupVersion()
 }
 
 // SetupWithConnection sets up the instance with an existing database connection.
 func (i *Instance) SetupWithConnection(db *sql.DB) error {
 	i.db = db
 	i.closeDB = false // we're borrowing this connection, don't close it
+	i.resetRecovery()
 
-	version, err := queryVersion(i.db)
+	return i.setupVersion()
+}
+
+// setupVersion sets the server version, either from the configured override
+// or by querying the server.
+func (i *Instance) setupVersion() error {
+	if i.versionOverride != nil {
+		i.version = *i.versionOverride
+		return nil
+	}
+
+	version, versionString, err := queryVersion(i.db)
 	if err != nil {
 		return fmt.Errorf("error querying postgresql version: %w", err)
 	}
 	i.version = version
+	i.versionString = versionString
 	return nil
 }
 
@@ -87,6 +125,44 @@ func (i *Instance) getDB() *sql.DB {
 	return i.db
 }
 
+// isInRecovery reports whether the server is a standby. Collectors running
+// concurrently in the same scrape share a single query.
+func (i *Instance) isInRecovery(ctx context.Context) (bool, error) {
+	i.recoveryMu.Lock()
+	defer i.recoveryMu.Unlock()
+	if i.inRecovery != nil {
+		return *i.inRecovery, nil
+	}
+	var inRecovery bool
+	if err := i.db.QueryRowContext(ctx, "SELECT pg_is_in_recovery();").Scan(&inRecovery); err != nil {
+		return false, err
+	}
+	i.inRecovery = &inRecovery
+	return inRecovery, nil
+}
+
+// cachedRole returns the server role if the recovery state has already been
+// queried during this scrape.
+func (i *Instance) cachedRole() (string, bool) {
+	i.recoveryMu.Lock()
+	defer i.recoveryMu.Unlock()
+	if i.inRecovery == nil {
+		return "", false
+	}
+	return serverRole(*i.inRecovery), true
+}
+
+func (i *Instance) resetRecovery() {
+	i.recoveryMu.Lock()
+	defer i.recoveryMu.Unlock()
+	i.inRecovery = nil
+}
+
+// ping verifies the connection is still alive.
+func (i *Instance) ping(ctx context.Context) error {
+	return i.db.PingContext(ctx)
+}
+
 func (i *Instance) Close() error {
 	if i.closeDB {
 		return i.db.Close()
@@ -99,28 +175,32 @@ func (i *Instance) Close() error {
 var versionRegex = regexp.MustCompile(`^\w+ ((\d+)(\.\d+)?(\.\d+)?)`)
 var serverVersionRegex = regexp.MustCompile(`^((\d+)(\.\d+)?(\.\d+)?)`)
 
-func queryVersion(db *sql.DB) (semver.Version, error) {
+// queryVersion returns the server version along with the raw string it was
+// parsed from.
+func queryVersion(db *sql.DB) (semver.Version, string, error) {
 	var version string
 	err := db.QueryRow("SELECT version();").Scan(&version)
 	if err != nil {
-		return semver.Version{}, err
+		return semver.Version{}, "", err
 	}
 	submatches := versionRegex.FindStringSubmatch(version)
 	if len(submatches) > 1 {
-		return semver.ParseTolerant(submatches[1])
+		v, err := semver.ParseTolerant(submatches[1])
+		return v, version, err
 	}
 
 	// We could also try to parse the version from the server_version field.
 	// This is of the format 13.3 (Debian 13.3-1.pgdg100+1)
 	err = db.QueryRow("SHOW server_version;").Scan(&version)
 	if err != nil {
-		return semver.Version{}, err
+		return semver.Version{}, "", err
 	}
 	submatches = serverVersionRegex.FindStringSubmatch(version)
 	if len(submatches) > 1 {
-		return semver.ParseTolerant(submatches[1])
+		v, err := semver.ParseTolerant(submatches[1])
+		return v, version, err
 	}
-	return semver.Version{}, fmt.Errorf("could not parse version from %q", version)
+	return semver.Version{}, "", fmt.Errorf("could not parse version from %q", version)
 }
 
 // InstanceFactory creates instances for collectors to use
@@ -0,0 +1,72 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package collector
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/blang/semver/v4"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/promslog"
+)
+
+func TestInstanceVersionOverride(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error opening a stub db connection: %s", err)
+	}
+	defer db.Close()
+
+	inst, err := NewInstance("postgresql://localhost:5432/postgres", WithVersionOverride(semver.MustParse("16.2.0")))
+	if err != nil {
+		t.Fatalf("Error creating instance: %s", err)
+	}
+
+	// No queries are expected: the override must bypass SELECT version().
+	if err := inst.SetupWithConnection(db); err != nil {
+		t.Fatalf("Error setting up instance: %s", err)
+	}
+	if !inst.version.EQ(semver.MustParse("16.2.0")) {
+		t.Errorf("expected version 16.2.0, got %s", inst.version)
+	}
+
+	// A collector gated on PostgreSQL 17 must skip without querying.
+	ch := make(chan prometheus.Metric)
+	go func() {
+		defer close(ch)
+		c := PGSynchronizedStandbySlotsCollector{log: promslog.NewNopLogger()}
+		if err := c.Update(context.Background(), inst, ch); err != nil {
+			t.Errorf("Error calling PGSynchronizedStandbySlotsCollector.Update: %s", err)
+		}
+	}()
+	for range ch {
+		t.Errorf("expected no metrics from a version-gated collector")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
+
+func TestInstanceCopyKeepsVersionOverride(t *testing.T) {
+	inst, err := NewInstance("postgresql://localhost:5432/postgres", WithVersionOverride(semver.MustParse("15.0.0")))
+	if err != nil {
+		t.Fatalf("Error creating instance: %s", err)
+	}
+	c := inst.copy()
+	if c.versionOverride == nil || !c.versionOverride.EQ(semver.MustParse("15.0.0")) {
+		t.Errorf("expected copied instance to keep the version override")
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"math"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const analyzeSubsystem = "analyze"
+
+var analyzeMinModsFlag *uint = nil
+
+func init() {
+	registerCollector(analyzeSubsystem, defaultDisabled, NewPGAnalyzeCollector)
+
+	analyzeMinModsFlag = kingpin.Flag(
+		fmt.Sprint(collectorFlagPrefix, analyzeSubsystem, ".min-mods"),
+		"Only report tables with at least this many rows modified since the last analyze.").
+		Default("0").
+		Uint()
+}
+
+// PGAnalyzeCollector reports how stale the planner statistics of each user
+// table are. Tables that have never been auto-analyzed report +Inf as their
+// age so that "older than" alerts fire for them too.
+type PGAnalyzeCollector struct {
+	log     *slog.Logger
+	minMods uint
+}
+
+func NewPGAnalyzeCollector(config collectorConfig) (Collector, error) {
+	return &PGAnalyzeCollector{
+		log:     config.logger,
+		minMods: *analyzeMinModsFlag,
+	}, nil
+}
+
+var (
+	pgTableLastAutoanalyzeSecondsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "table", "last_autoanalyze_seconds"),
+		"Seconds since the table was last analyzed by autovacuum (+Inf if never)",
+		[]string{"datname", "schemaname", "relname"}, nil,
+	)
+	pgTableModSinceAnalyzeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "table", "mod_since_analyze"),
+		"Estimated number of rows modified since the table was last analyzed",
+		[]string{"datname", "schemaname", "relname"}, nil,
+	)
+
+	pgAnalyzeQuery = `SELECT
+		current_database() AS datname,
+		schemaname,
+		relname,
+		EXTRACT(EPOCH FROM clock_timestamp() - last_autoanalyze) AS last_autoanalyze_age,
+		n_mod_since_analyze
+	FROM pg_catalog.pg_stat_user_tables
+	WHERE n_mod_since_analyze >= $1`
+)
+
+func (c PGAnalyzeCollector) Update(ctx context.Context, instance *Instance, ch chan<- prometheus.Metric) error {
+	db := instance.getDB()
+	rows, err := queryWithOverride(ctx, db, analyzeSubsystem, pgAnalyzeQuery, 5, c.minMods)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var datname, schemaname, relname sql.NullString
+		var age sql.NullFloat64
+		var modSinceAnalyze sql.NullInt64
+		if err := rows.Scan(&datname, &schemaname, &relname, &age, &modSinceAnalyze); err != nil {
+			return err
+		}
+
+		if !datname.Valid || !schemaname.Valid || !relname.Valid {
+			continue
+		}
+		labels := []string{datname.String, schemaname.String, relname.String}
+
+		ageMetric := math.Inf(1)
+		if age.Valid {
+			ageMetric = age.Float64
+		}
+		ch <- prometheus.MustNewConstMetric(
+			pgTableLastAutoanalyzeSecondsDesc,
+			prometheus.GaugeValue, ageMetric, labels...,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			pgTableModSinceAnalyzeDesc,
+			prometheus.GaugeValue, float64(modSinceAnalyze.Int64), labels...,
+		)
+	}
+	return rows.Err()
+}
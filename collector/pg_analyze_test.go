@@ -0,0 +1,72 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package collector
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+)
+
+func TestPGAnalyzeCollector(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error opening a stub db connection: %s", err)
+	}
+	defer db.Close()
+
+	inst := &Instance{db: db}
+
+	columns := []string{"datname", "schemaname", "relname", "last_autoanalyze_age", "n_mod_since_analyze"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("postgres", "public", "orders", 3600.5, 5000).
+		AddRow("postgres", "public", "events", nil, 1200)
+	mock.ExpectQuery(sanitizeQuery(pgAnalyzeQuery)).WithArgs(1000).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		defer close(ch)
+		c := PGAnalyzeCollector{minMods: 1000}
+
+		if err := c.Update(context.Background(), inst, ch); err != nil {
+			t.Errorf("Error calling PGAnalyzeCollector.Update: %s", err)
+		}
+	}()
+
+	var metrics []MetricResult
+	for m := range ch {
+		metrics = append(metrics, readMetric(m))
+	}
+
+	expected := []MetricResult{
+		{labels: labelMap{"datname": "postgres", "schemaname": "public", "relname": "orders"}, value: 3600.5, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"datname": "postgres", "schemaname": "public", "relname": "orders"}, value: 5000, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"datname": "postgres", "schemaname": "public", "relname": "events"}, value: math.Inf(1), metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"datname": "postgres", "schemaname": "public", "relname": "events"}, value: 1200, metricType: dto.MetricType_GAUGE},
+	}
+
+	convey.Convey("Metrics comparison", t, func() {
+		convey.So(metrics, convey.ShouldHaveLength, len(expected))
+		for i, expect := range expected {
+			convey.So(expect, convey.ShouldResemble, metrics[i])
+		}
+	})
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
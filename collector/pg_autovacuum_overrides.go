@@ -0,0 +1,109 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const autovacuumOverridesSubsystem = "autovacuum_overrides"
+
+func init() {
+	registerCollector(autovacuumOverridesSubsystem, defaultDisabled, NewPGAutovacuumOverridesCollector)
+}
+
+// PGAutovacuumOverridesCollector reports per-table autovacuum_* storage
+// parameters that override the server-wide autovacuum settings, so that
+// forgotten overrides such as autovacuum_enabled=false can be caught.
+type PGAutovacuumOverridesCollector struct {
+	log *slog.Logger
+}
+
+func NewPGAutovacuumOverridesCollector(config collectorConfig) (Collector, error) {
+	return &PGAutovacuumOverridesCollector{log: config.logger}, nil
+}
+
+var (
+	pgAutovacuumOverrideDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "table", "autovacuum_override"),
+		"Table has an autovacuum storage parameter override (value is always 1)",
+		[]string{"datname", "schemaname", "relname", "option", "value"}, nil,
+	)
+	pgAutovacuumDisabledDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "table", "autovacuum_disabled"),
+		"Autovacuum is disabled for the table with autovacuum_enabled=false (value is always 1)",
+		[]string{"datname", "schemaname", "relname"}, nil,
+	)
+
+	pgAutovacuumOverridesQuery = `SELECT
+		current_database() AS datname,
+		n.nspname AS schemaname,
+		c.relname,
+		o.option_name,
+		o.option_value
+	FROM pg_catalog.pg_class c
+	JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+	CROSS JOIN LATERAL pg_catalog.pg_options_to_table(c.reloptions) o
+	WHERE c.relkind IN ('r', 'm')
+		AND o.option_name LIKE 'autovacuum%'
+	ORDER BY n.nspname, c.relname, o.option_name`
+)
+
+func (c PGAutovacuumOverridesCollector) Update(ctx context.Context, instance *Instance, ch chan<- prometheus.Metric) error {
+	db := instance.getDB()
+	rows, err := queryWithOverride(ctx, db, autovacuumOverridesSubsystem, pgAutovacuumOverridesQuery, 5)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var datname, schemaname, relname, option, value sql.NullString
+		if err := rows.Scan(&datname, &schemaname, &relname, &option, &value); err != nil {
+			return err
+		}
+
+		if !datname.Valid || !schemaname.Valid || !relname.Valid || !option.Valid {
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(
+			pgAutovacuumOverrideDesc,
+			prometheus.GaugeValue, 1,
+			datname.String, schemaname.String, relname.String, option.String, value.String,
+		)
+		if option.String == "autovacuum_enabled" && isFalseReloption(value.String) {
+			ch <- prometheus.MustNewConstMetric(
+				pgAutovacuumDisabledDesc,
+				prometheus.GaugeValue, 1,
+				datname.String, schemaname.String, relname.String,
+			)
+		}
+	}
+	return rows.Err()
+}
+
+// isFalseReloption reports whether a boolean storage parameter value, stored
+// as written by the user, is one of the spellings PostgreSQL accepts as false.
+func isFalseReloption(value string) bool {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "false", "f", "off", "no", "n", "0", "fa", "fal", "fals", "of":
+		return true
+	}
+	return false
+}
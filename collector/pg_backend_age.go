@@ -0,0 +1,126 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const backendAgeSubsystem = "backend_age"
+
+func init() {
+	registerCollector(backendAgeSubsystem, defaultDisabled, NewPGBackendAgeCollector)
+}
+
+// PGBackendAgeCollector reports how long backends have been connected, to
+// spot client pools that leak connections.
+type PGBackendAgeCollector struct {
+	log                     *slog.Logger
+	excludeApplicationNames []string
+	skipOnStandby           bool
+	roleLabel               bool
+}
+
+func NewPGBackendAgeCollector(config collectorConfig) (Collector, error) {
+	return &PGBackendAgeCollector{
+		log:                     config.logger,
+		excludeApplicationNames: excludeApplicationNames(),
+		skipOnStandby:           activitySkipOnStandby(),
+		roleLabel:               activityRoleLabel(),
+	}, nil
+}
+
+// backendAgeBuckets range from a minute to a week.
+var backendAgeBuckets = []float64{60, 300, 900, 3600, 6 * 3600, 24 * 3600, 7 * 24 * 3600}
+
+var (
+	pgBackendAgeSecondsDesc = newActivityDesc(
+		prometheus.BuildFQName(namespace, "backend", "age_seconds"),
+		"Time since each backend connected",
+		[]string{},
+	)
+	pgOldestBackendAgeSecondsDesc = newActivityDesc(
+		prometheus.BuildFQName(namespace, "", "oldest_backend_age_seconds"),
+		"Time since the longest-connected backend connected",
+		[]string{},
+	)
+
+	pgBackendAgeQueryBase = `SELECT
+		EXTRACT(EPOCH FROM clock_timestamp() - backend_start) AS backend_age
+	FROM pg_catalog.pg_stat_activity`
+)
+
+// query returns the backend age query. Background workers and other backends
+// without a client connection have no client_port and are left out, so only
+// client connections count towards the histogram.
+func (c PGBackendAgeCollector) query() string {
+	return newQueryBuilder(pgBackendAgeQueryBase).
+		where("backend_start IS NOT NULL").
+		where("client_port IS NOT NULL").
+		where(excludeSelfCondition(c.excludeApplicationNames)).
+		String()
+}
+
+func (c PGBackendAgeCollector) Update(ctx context.Context, instance *Instance, ch chan<- prometheus.Metric) error {
+	role, skip, err := activityRole(ctx, instance, c.skipOnStandby, c.roleLabel, c.log)
+	if err != nil || skip {
+		return err
+	}
+	db := instance.getDB()
+	rows, err := queryWithOverride(ctx, db, backendAgeSubsystem, c.query(), 1)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	buckets := make(map[float64]uint64, len(backendAgeBuckets))
+	for _, le := range backendAgeBuckets {
+		buckets[le] = 0
+	}
+	var count uint64
+	var sum, oldest float64
+	for rows.Next() {
+		var age sql.NullFloat64
+		if err := rows.Scan(&age); err != nil {
+			return err
+		}
+		if !age.Valid {
+			continue
+		}
+		count++
+		sum += age.Float64
+		oldest = max(oldest, age.Float64)
+		for _, le := range backendAgeBuckets {
+			if age.Float64 <= le {
+				buckets[le]++
+			}
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	ch <- pgOldestBackendAgeSecondsDesc.mustNewConstMetric(
+		role, prometheus.GaugeValue, oldest,
+	)
+	ch <- prometheus.MustNewConstHistogram(
+		pgBackendAgeSecondsDesc.desc(role),
+		count, sum, buckets, pgBackendAgeSecondsDesc.labelValues(role)...,
+	)
+	return nil
+}
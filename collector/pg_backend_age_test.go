@@ -0,0 +1,80 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package collector
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+)
+
+func TestPGBackendAgeCollector(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error opening a stub db connection: %s", err)
+	}
+	defer db.Close()
+
+	inst := &Instance{db: db}
+
+	rows := sqlmock.NewRows([]string{"backend_age"}).
+		AddRow(30).
+		AddRow(1800).
+		AddRow(172800)
+	mock.ExpectQuery(sanitizeQuery(PGBackendAgeCollector{}.query())).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		defer close(ch)
+		c := PGBackendAgeCollector{}
+
+		if err := c.Update(context.Background(), inst, ch); err != nil {
+			t.Errorf("Error calling PGBackendAgeCollector.Update: %s", err)
+		}
+	}()
+
+	convey.Convey("Metrics comparison", t, func() {
+		oldest := readMetric(<-ch)
+		convey.So(oldest, convey.ShouldResemble, MetricResult{labels: labelMap{}, value: 172800, metricType: dto.MetricType_GAUGE})
+
+		pb := &dto.Metric{}
+		convey.So((<-ch).Write(pb), convey.ShouldBeNil)
+		h := pb.GetHistogram()
+		convey.So(h.GetSampleCount(), convey.ShouldEqual, 3)
+		convey.So(h.GetSampleSum(), convey.ShouldEqual, 174630)
+
+		cumulative := make(map[float64]uint64)
+		for _, b := range h.GetBucket() {
+			cumulative[b.GetUpperBound()] = b.GetCumulativeCount()
+		}
+		convey.So(cumulative[60], convey.ShouldEqual, 1)
+		convey.So(cumulative[3600], convey.ShouldEqual, 2)
+		convey.So(cumulative[7*24*3600], convey.ShouldEqual, 3)
+	})
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
+
+func TestPGBackendAgeCollectorSkipsBackgroundWorkers(t *testing.T) {
+	// Background workers such as the autovacuum launcher have a
+	// backend_start but no client_port.
+	if q := (PGBackendAgeCollector{}).query(); !strings.Contains(q, "client_port IS NOT NULL") {
+		t.Errorf("expected the query to leave out backends without a client port, got %q", q)
+	}
+}
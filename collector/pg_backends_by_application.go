@@ -0,0 +1,183 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"sort"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const backendsByApplicationSubsystem = "backends_by_application"
+
+var backendsByApplicationLimitFlag *uint = nil
+
+func init() {
+	registerCollector(backendsByApplicationSubsystem, defaultDisabled, NewPGBackendsByApplicationCollector)
+
+	backendsByApplicationLimitFlag = kingpin.Flag(
+		fmt.Sprint(collectorFlagPrefix, backendsByApplicationSubsystem, ".limit"),
+		"Number of application names with the most backends to report individually; the rest are reported as \"other\". An application actually named \"other\" or \"unnamed\" is counted together with that bucket.").
+		Default("20").
+		Uint()
+}
+
+const (
+	// backendsByApplicationUnnamed labels backends without an application_name.
+	backendsByApplicationUnnamed = "unnamed"
+	// backendsByApplicationOther labels backends of applications past the limit.
+	backendsByApplicationOther = "other"
+)
+
+// PGBackendsByApplicationCollector counts client backends by application_name
+// and state, to attribute connection pressure to services. Only the
+// applications with the most backends are reported by name so that clients
+// setting unique application names cannot blow up cardinality.
+//
+// The "unnamed" and "other" buckets share the application_name label with
+// real applications, so an application that sets one of those names is
+// reported together with the bucket.
+type PGBackendsByApplicationCollector struct {
+	log                     *slog.Logger
+	limit                   uint
+	excludeApplicationNames []string
+	skipOnStandby           bool
+	roleLabel               bool
+}
+
+func NewPGBackendsByApplicationCollector(config collectorConfig) (Collector, error) {
+	return &PGBackendsByApplicationCollector{
+		log:                     config.logger,
+		limit:                   *backendsByApplicationLimitFlag,
+		excludeApplicationNames: excludeApplicationNames(),
+		skipOnStandby:           activitySkipOnStandby(),
+		roleLabel:               activityRoleLabel(),
+	}, nil
+}
+
+var (
+	pgBackendsByApplicationDesc = newActivityDesc(
+		prometheus.BuildFQName(namespace, "", "backends_by_application"),
+		"Number of client backends by application_name and state",
+		[]string{"application_name", "state"},
+	)
+
+	pgBackendsByApplicationQueryBase = `SELECT
+		application_name,
+		state,
+		count(*) AS backends
+	FROM pg_catalog.pg_stat_activity`
+)
+
+func (c PGBackendsByApplicationCollector) query() string {
+	q := newQueryBuilder(pgBackendsByApplicationQueryBase).
+		where("client_port IS NOT NULL").
+		where(excludeSelfCondition(c.excludeApplicationNames))
+	return terminateQuery(q.subquery() + "\nGROUP BY application_name, state")
+}
+
+type backendsByApplicationKey struct {
+	application string
+	state       string
+}
+
+func (c PGBackendsByApplicationCollector) Update(ctx context.Context, instance *Instance, ch chan<- prometheus.Metric) error {
+	role, skip, err := activityRole(ctx, instance, c.skipOnStandby, c.roleLabel, c.log)
+	if err != nil || skip {
+		return err
+	}
+	db := instance.getDB()
+	rows, err := queryWithOverride(ctx, db, backendsByApplicationSubsystem, c.query(), 3)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	counts := map[backendsByApplicationKey]float64{}
+	totals := map[string]float64{}
+	for rows.Next() {
+		var application, state sql.NullString
+		var backends sql.NullFloat64
+		if err := rows.Scan(&application, &state, &backends); err != nil {
+			return err
+		}
+		key := backendsByApplicationKey{application: application.String, state: state.String}
+		if key.application == "" {
+			key.application = backendsByApplicationUnnamed
+		}
+		if key.state == "" {
+			key.state = "unknown"
+		}
+		counts[key] += backends.Float64
+		totals[key.application] += backends.Float64
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	named := topBackendApplications(totals, int(c.limit))
+	bucketed := map[backendsByApplicationKey]float64{}
+	for key, count := range counts {
+		if _, ok := named[key.application]; !ok {
+			key.application = backendsByApplicationOther
+		}
+		bucketed[key] += count
+	}
+
+	keys := make([]backendsByApplicationKey, 0, len(bucketed))
+	for key := range bucketed {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].application != keys[j].application {
+			return keys[i].application < keys[j].application
+		}
+		return keys[i].state < keys[j].state
+	})
+	for _, key := range keys {
+		ch <- pgBackendsByApplicationDesc.mustNewConstMetric(
+			role, prometheus.GaugeValue, bucketed[key],
+			key.application, key.state,
+		)
+	}
+	return nil
+}
+
+// topBackendApplications returns the limit applications with the most
+// backends, breaking ties by name so the selection is stable across scrapes.
+func topBackendApplications(totals map[string]float64, limit int) map[string]struct{} {
+	applications := make([]string, 0, len(totals))
+	for application := range totals {
+		applications = append(applications, application)
+	}
+	sort.Slice(applications, func(i, j int) bool {
+		if totals[applications[i]] != totals[applications[j]] {
+			return totals[applications[i]] > totals[applications[j]]
+		}
+		return applications[i] < applications[j]
+	})
+	if len(applications) > limit {
+		applications = applications[:limit]
+	}
+	named := make(map[string]struct{}, len(applications))
+	for _, application := range applications {
+		named[application] = struct{}{}
+	}
+	return named
+}
@@ -0,0 +1,115 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package collector
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+)
+
+func TestPGBackendsByApplicationCollector(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error opening a stub db connection: %s", err)
+	}
+	defer db.Close()
+
+	inst := &Instance{db: db}
+	c := PGBackendsByApplicationCollector{limit: 2}
+
+	columns := []string{"application_name", "state", "backends"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("api", "active", 1).
+		AddRow("api", "idle", 2).
+		AddRow("worker", "active", 1).
+		AddRow("worker", "idle in transaction", 1).
+		AddRow("billing", "active", 1).
+		AddRow("", "idle", 1)
+	mock.ExpectQuery(sanitizeQuery(c.query())).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		defer close(ch)
+		if err := c.Update(context.Background(), inst, ch); err != nil {
+			t.Errorf("Error calling PGBackendsByApplicationCollector.Update: %s", err)
+		}
+	}()
+
+	// Only the two applications with the most backends are named; billing
+	// and the unnamed backend fall into the other bucket.
+	expected := []MetricResult{
+		{labels: labelMap{"application_name": "api", "state": "active"}, value: 1, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"application_name": "api", "state": "idle"}, value: 2, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"application_name": "other", "state": "active"}, value: 1, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"application_name": "other", "state": "idle"}, value: 1, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"application_name": "worker", "state": "active"}, value: 1, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"application_name": "worker", "state": "idle in transaction"}, value: 1, metricType: dto.MetricType_GAUGE},
+	}
+
+	convey.Convey("Metrics comparison", t, func() {
+		var metrics []MetricResult
+		for m := range ch {
+			metrics = append(metrics, readMetric(m))
+		}
+		convey.So(metrics, convey.ShouldResemble, expected)
+	})
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
+
+func TestPGBackendsByApplicationCollectorUnnamed(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error opening a stub db connection: %s", err)
+	}
+	defer db.Close()
+
+	inst := &Instance{db: db}
+	c := PGBackendsByApplicationCollector{limit: 20}
+
+	rows := sqlmock.NewRows([]string{"application_name", "state", "backends"}).
+		AddRow(nil, "idle", 1).
+		AddRow("", "active", 1).
+		AddRow("", "idle", 2)
+	mock.ExpectQuery(sanitizeQuery(c.query())).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		defer close(ch)
+		if err := c.Update(context.Background(), inst, ch); err != nil {
+			t.Errorf("Error calling PGBackendsByApplicationCollector.Update: %s", err)
+		}
+	}()
+
+	expected := []MetricResult{
+		{labels: labelMap{"application_name": "unnamed", "state": "active"}, value: 1, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"application_name": "unnamed", "state": "idle"}, value: 3, metricType: dto.MetricType_GAUGE},
+	}
+
+	convey.Convey("Backends without an application_name are grouped as unnamed", t, func() {
+		var metrics []MetricResult
+		for m := range ch {
+			metrics = append(metrics, readMetric(m))
+		}
+		convey.So(metrics, convey.ShouldResemble, expected)
+	})
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
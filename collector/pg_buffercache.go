@@ -0,0 +1,119 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const buffercacheSubsystem = "buffercache"
+
+func init() {
+	registerCollector(buffercacheSubsystem, defaultDisabled, NewPGBuffercacheCollector)
+}
+
+// PGBuffercacheCollector reports shared buffer utilization from the
+// pg_buffercache view. Unlike buffercache_summary it works on servers older
+// than PostgreSQL 16, at the cost of scanning every buffer header.
+//
+// It depends on the extension being installed with
+//
+//	create extension pg_buffercache;
+type PGBuffercacheCollector struct {
+	log *slog.Logger
+}
+
+func NewPGBuffercacheCollector(config collectorConfig) (Collector, error) {
+	return &PGBuffercacheCollector{log: config.logger}, nil
+}
+
+var (
+	pgBuffercacheUsedBuffersDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, buffercacheSubsystem, "used_buffers"),
+		"Number of shared buffers holding a relation page",
+		[]string{}, nil,
+	)
+	pgBuffercacheDirtyBuffersDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, buffercacheSubsystem, "dirty_buffers"),
+		"Number of dirty shared buffers",
+		[]string{}, nil,
+	)
+	pgBuffercacheUsageRatioDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, buffercacheSubsystem, "usage_ratio"),
+		"Ratio of used shared buffers to the total number of shared buffers",
+		[]string{}, nil,
+	)
+
+	pgBuffercacheExtensionQuery = "SELECT EXISTS (SELECT 1 FROM pg_catalog.pg_extension WHERE extname = 'pg_buffercache')"
+
+	pgBuffercacheQuery = `SELECT
+		count(*) FILTER (WHERE relfilenode IS NOT NULL) AS used,
+		count(*) FILTER (WHERE isdirty) AS dirty,
+		count(*) AS total
+	FROM pg_buffercache`
+)
+
+func (c PGBuffercacheCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- pgBuffercacheUsedBuffersDesc
+	ch <- pgBuffercacheDirtyBuffersDesc
+	ch <- pgBuffercacheUsageRatioDesc
+}
+
+func (c PGBuffercacheCollector) Update(ctx context.Context, instance *Instance, ch chan<- prometheus.Metric) error {
+	db := instance.getDB()
+
+	var installed bool
+	if err := db.QueryRowContext(ctx, pgBuffercacheExtensionQuery).Scan(&installed); err != nil {
+		return err
+	}
+	if !installed {
+		return ErrNoData
+	}
+
+	var used, dirty, total sql.NullInt64
+	if err := scanRowWithOverride(ctx, db, buffercacheSubsystem, pgBuffercacheQuery, nil, &used, &dirty, &total); err != nil {
+		return err
+	}
+
+	usedMetric := 0.0
+	if used.Valid {
+		usedMetric = float64(used.Int64)
+	}
+	dirtyMetric := 0.0
+	if dirty.Valid {
+		dirtyMetric = float64(dirty.Int64)
+	}
+	ratioMetric := 0.0
+	if total.Valid && total.Int64 > 0 {
+		ratioMetric = usedMetric / float64(total.Int64)
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		pgBuffercacheUsedBuffersDesc,
+		prometheus.GaugeValue, usedMetric,
+	)
+	ch <- prometheus.MustNewConstMetric(
+		pgBuffercacheDirtyBuffersDesc,
+		prometheus.GaugeValue, dirtyMetric,
+	)
+	ch <- prometheus.MustNewConstMetric(
+		pgBuffercacheUsageRatioDesc,
+		prometheus.GaugeValue, ratioMetric,
+	)
+	return nil
+}
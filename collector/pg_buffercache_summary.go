@@ -94,10 +94,10 @@ var (
 func (c BuffercacheSummaryCollector) Update(ctx context.Context, instance *Instance, ch chan<- prometheus.Metric) error {
 	// pg_buffercache_summary is only in v16, and we don't need support for earlier currently.
 	if !instance.version.GE(semver.MustParse("16.0.0")) {
-		return nil
+		return skipUnsupportedVersion(c.log, "pg_buffercache_summary() is not available before PostgreSQL 16")
 	}
 	db := instance.getDB()
-	rows, err := db.QueryContext(ctx, buffercacheQuery)
+	rows, err := queryWithOverride(ctx, db, buffercacheSummarySubsystem, buffercacheQuery, 5)
 	if err != nil {
 		return err
 	}
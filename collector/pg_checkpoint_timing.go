@@ -0,0 +1,88 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+
+	"github.com/blang/semver/v4"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const checkpointTimingSubsystem = "checkpoint_timing"
+
+func init() {
+	registerCollector(checkpointTimingSubsystem, defaultDisabled, NewPGCheckpointTimingCollector)
+}
+
+// PGCheckpointTimingCollector reports the time checkpoints spend writing and
+// syncing files, in seconds, under the same names on every version. The
+// columns live in pg_stat_bgwriter before PostgreSQL 17 and in
+// pg_stat_checkpointer from 17 on.
+type PGCheckpointTimingCollector struct {
+	log *slog.Logger
+}
+
+func NewPGCheckpointTimingCollector(config collectorConfig) (Collector, error) {
+	return &PGCheckpointTimingCollector{log: config.logger}, nil
+}
+
+var (
+	pgCheckpointWriteTimeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "checkpoint", "write_time_seconds_total"),
+		"Time spent in the portion of checkpoint processing where files are written to disk, in seconds",
+		[]string{}, nil,
+	)
+	pgCheckpointSyncTimeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "checkpoint", "sync_time_seconds_total"),
+		"Time spent in the portion of checkpoint processing where files are synchronized to disk, in seconds",
+		[]string{}, nil,
+	)
+
+	pgCheckpointTimingBgwriterQuery = `SELECT
+		checkpoint_write_time,
+		checkpoint_sync_time
+	FROM pg_catalog.pg_stat_bgwriter`
+
+	pgCheckpointTimingCheckpointerQuery = `SELECT
+		write_time,
+		sync_time
+	FROM pg_catalog.pg_stat_checkpointer`
+)
+
+func (c PGCheckpointTimingCollector) Update(ctx context.Context, instance *Instance, ch chan<- prometheus.Metric) error {
+	query := pgCheckpointTimingBgwriterQuery
+	if instance.version.GTE(semver.MustParse("17.0.0")) {
+		query = pgCheckpointTimingCheckpointerQuery
+	}
+
+	db := instance.getDB()
+	var writeTime, syncTime sql.NullFloat64
+	if err := scanRowWithOverride(ctx, db, checkpointTimingSubsystem, query, nil, &writeTime, &syncTime); err != nil {
+		return err
+	}
+
+	// Both columns are reported in milliseconds.
+	ch <- prometheus.MustNewConstMetric(
+		pgCheckpointWriteTimeDesc,
+		prometheus.CounterValue, writeTime.Float64/1000,
+	)
+	ch <- prometheus.MustNewConstMetric(
+		pgCheckpointSyncTimeDesc,
+		prometheus.CounterValue, syncTime.Float64/1000,
+	)
+	return nil
+}
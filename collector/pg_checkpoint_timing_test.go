@@ -0,0 +1,76 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package collector
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/blang/semver/v4"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+)
+
+func TestPGCheckpointTimingCollector(t *testing.T) {
+	cases := []struct {
+		name    string
+		version string
+		query   string
+		columns []string
+	}{
+		{"pg_stat_bgwriter", "16.3.0", pgCheckpointTimingBgwriterQuery, []string{"checkpoint_write_time", "checkpoint_sync_time"}},
+		{"pg_stat_checkpointer", "17.0.0", pgCheckpointTimingCheckpointerQuery, []string{"write_time", "sync_time"}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("Error opening a stub db connection: %s", err)
+			}
+			defer db.Close()
+
+			inst := &Instance{db: db, version: semver.MustParse(tc.version)}
+
+			rows := sqlmock.NewRows(tc.columns).AddRow(123456.0, 2500.0)
+			mock.ExpectQuery(sanitizeQuery(tc.query)).WillReturnRows(rows)
+
+			ch := make(chan prometheus.Metric)
+			go func() {
+				defer close(ch)
+				c := PGCheckpointTimingCollector{}
+
+				if err := c.Update(context.Background(), inst, ch); err != nil {
+					t.Errorf("Error calling PGCheckpointTimingCollector.Update: %s", err)
+				}
+			}()
+
+			expected := []MetricResult{
+				{labels: labelMap{}, value: 123.456, metricType: dto.MetricType_COUNTER},
+				{labels: labelMap{}, value: 2.5, metricType: dto.MetricType_COUNTER},
+			}
+
+			convey.Convey("Metrics comparison", t, func() {
+				for _, expect := range expected {
+					m := readMetric(<-ch)
+					convey.So(expect, convey.ShouldResemble, m)
+				}
+			})
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled exceptions: %s", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const clusterDatfrozenxidSubsystem = "cluster_datfrozenxid"
+
+func init() {
+	registerCollector(clusterDatfrozenxidSubsystem, defaultDisabled, NewPGClusterDatfrozenxidCollector)
+}
+
+// PGClusterDatfrozenxidCollector reports the oldest datfrozenxid age in the
+// cluster as a single number for top-level wraparound alerting, plus an info
+// series naming the database it belongs to.
+type PGClusterDatfrozenxidCollector struct {
+	log *slog.Logger
+}
+
+func NewPGClusterDatfrozenxidCollector(config collectorConfig) (Collector, error) {
+	return &PGClusterDatfrozenxidCollector{log: config.logger}, nil
+}
+
+var (
+	pgClusterOldestDatfrozenxidAgeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "cluster", "oldest_datfrozenxid_age"),
+		"Largest age(datfrozenxid) of any database in the cluster",
+		[]string{}, nil,
+	)
+	pgClusterOldestDatfrozenxidInfoDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "cluster", "oldest_datfrozenxid_info"),
+		"Database with the largest age(datfrozenxid) (value is always 1)",
+		[]string{"datname"}, nil,
+	)
+
+	pgClusterDatfrozenxidQuery = `SELECT
+		datname,
+		age(datfrozenxid) AS age_datfrozenxid
+	FROM pg_catalog.pg_database`
+)
+
+func (c PGClusterDatfrozenxidCollector) Update(ctx context.Context, instance *Instance, ch chan<- prometheus.Metric) error {
+	db := instance.getDB()
+	rows, err := queryWithOverride(ctx, db, clusterDatfrozenxidSubsystem, pgClusterDatfrozenxidQuery, 2)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var oldestName string
+	var oldestAge float64
+	found := false
+	for rows.Next() {
+		var datname sql.NullString
+		var age sql.NullFloat64
+		if err := rows.Scan(&datname, &age); err != nil {
+			return err
+		}
+		if !datname.Valid || !age.Valid {
+			continue
+		}
+		if !found || age.Float64 > oldestAge {
+			oldestName, oldestAge, found = datname.String, age.Float64, true
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	if !found {
+		return ErrNoData
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		pgClusterOldestDatfrozenxidAgeDesc,
+		prometheus.GaugeValue, oldestAge,
+	)
+	ch <- prometheus.MustNewConstMetric(
+		pgClusterOldestDatfrozenxidInfoDesc,
+		prometheus.GaugeValue, 1, oldestName,
+	)
+	return nil
+}
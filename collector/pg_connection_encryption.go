@@ -0,0 +1,166 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+
+	"github.com/blang/semver/v4"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const connectionEncryptionSubsystem = "connection_encryption"
+
+func init() {
+	registerCollector(connectionEncryptionSubsystem, defaultDisabled, NewPGConnectionEncryptionCollector)
+}
+
+// PGConnectionEncryptionCollector counts client connections by whether they
+// are encrypted with SSL or GSSAPI, and SSL connections by negotiated TLS
+// version and cipher. The exporter's own backend and background processes
+// are not counted.
+type PGConnectionEncryptionCollector struct {
+	log                     *slog.Logger
+	excludeApplicationNames []string
+	skipOnStandby           bool
+	roleLabel               bool
+}
+
+func NewPGConnectionEncryptionCollector(config collectorConfig) (Collector, error) {
+	return &PGConnectionEncryptionCollector{
+		log:                     config.logger,
+		excludeApplicationNames: excludeApplicationNames(),
+		skipOnStandby:           activitySkipOnStandby(),
+		roleLabel:               activityRoleLabel(),
+	}, nil
+}
+
+var (
+	pgSSLConnectionsDesc = newActivityDesc(
+		prometheus.BuildFQName(namespace, "", "ssl_connections"),
+		"Number of client connections using SSL",
+		[]string{},
+	)
+	pgNonSSLConnectionsDesc = newActivityDesc(
+		prometheus.BuildFQName(namespace, "", "nonssl_connections"),
+		"Number of client connections not using SSL",
+		[]string{},
+	)
+	pgGSSConnectionsDesc = newActivityDesc(
+		prometheus.BuildFQName(namespace, "", "gss_connections"),
+		"Number of client connections using GSSAPI encryption",
+		[]string{},
+	)
+	pgSSLConnectionsByCipherDesc = newActivityDesc(
+		prometheus.BuildFQName(namespace, "", "ssl_connections_by_cipher"),
+		"Number of client connections using SSL by TLS version and cipher",
+		[]string{"version", "cipher"},
+	)
+
+	// The views share the pid column with pg_stat_activity; joining with
+	// USING keeps the unqualified pid in excludeSelfCondition valid.
+	pgConnectionEncryptionQueryBase = `SELECT
+		count(*) FILTER (WHERE ssl) AS ssl,
+		count(*) FILTER (WHERE NOT ssl) AS nonssl
+	FROM pg_catalog.pg_stat_activity
+	JOIN pg_catalog.pg_stat_ssl USING (pid)`
+
+	pgConnectionEncryptionQueryBasePG12 = `SELECT
+		count(*) FILTER (WHERE ssl) AS ssl,
+		count(*) FILTER (WHERE NOT ssl) AS nonssl,
+		count(*) FILTER (WHERE encrypted) AS gss
+	FROM pg_catalog.pg_stat_activity
+	JOIN pg_catalog.pg_stat_ssl USING (pid)
+	JOIN pg_catalog.pg_stat_gssapi USING (pid)`
+
+	pgSSLConnectionsByCipherQueryBase = `SELECT
+		COALESCE(version, '') AS version,
+		COALESCE(cipher, '') AS cipher,
+		count(*) AS connections
+	FROM pg_catalog.pg_stat_activity
+	JOIN pg_catalog.pg_stat_ssl USING (pid)`
+)
+
+func (c PGConnectionEncryptionCollector) query(hasGSS bool) string {
+	base := pgConnectionEncryptionQueryBase
+	if hasGSS {
+		base = pgConnectionEncryptionQueryBasePG12
+	}
+	return newQueryBuilder(base).
+		where("client_port IS NOT NULL").
+		where(excludeSelfCondition(c.excludeApplicationNames)).
+		String()
+}
+
+func (c PGConnectionEncryptionCollector) cipherQuery() string {
+	q := newQueryBuilder(pgSSLConnectionsByCipherQueryBase).
+		where("ssl").
+		where("client_port IS NOT NULL").
+		where(excludeSelfCondition(c.excludeApplicationNames))
+	return terminateQuery(q.subquery() + "\nGROUP BY 1, 2\nORDER BY 1, 2")
+}
+
+func (c PGConnectionEncryptionCollector) Update(ctx context.Context, instance *Instance, ch chan<- prometheus.Metric) error {
+	if instance.version.LT(semver.MustParse("9.5.0")) {
+		return skipUnsupportedVersion(c.log, "pg_stat_ssl is not available before PostgreSQL 9.5")
+	}
+	hasGSS := instance.version.GTE(semver.MustParse("12.0.0"))
+
+	role, skip, err := activityRole(ctx, instance, c.skipOnStandby, c.roleLabel, c.log)
+	if err != nil || skip {
+		return err
+	}
+	db := instance.getDB()
+	var ssl, nonSSL, gss sql.NullInt64
+	dest := []any{&ssl, &nonSSL}
+	if hasGSS {
+		dest = append(dest, &gss)
+	}
+	if err := scanRowWithOverride(ctx, db, connectionEncryptionSubsystem, c.query(hasGSS), nil, dest...); err != nil {
+		return err
+	}
+
+	ch <- pgSSLConnectionsDesc.mustNewConstMetric(
+		role, prometheus.GaugeValue, float64(ssl.Int64),
+	)
+	ch <- pgNonSSLConnectionsDesc.mustNewConstMetric(
+		role, prometheus.GaugeValue, float64(nonSSL.Int64),
+	)
+	if hasGSS {
+		ch <- pgGSSConnectionsDesc.mustNewConstMetric(
+			role, prometheus.GaugeValue, float64(gss.Int64),
+		)
+	}
+
+	rows, err := db.QueryContext(ctx, c.cipherQuery())
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var version, cipher sql.NullString
+		var connections sql.NullInt64
+		if err := rows.Scan(&version, &cipher, &connections); err != nil {
+			return err
+		}
+		ch <- pgSSLConnectionsByCipherDesc.mustNewConstMetric(
+			role, prometheus.GaugeValue, float64(connections.Int64),
+			version.String, cipher.String,
+		)
+	}
+	return rows.Err()
+}
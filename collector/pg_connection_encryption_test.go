@@ -0,0 +1,85 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package collector
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/blang/semver/v4"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+)
+
+func TestPGConnectionEncryptionCollector(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error opening a stub db connection: %s", err)
+	}
+	defer db.Close()
+
+	inst := &Instance{db: db, version: semver.MustParse("16.0.0")}
+
+	columns := []string{"ssl", "nonssl", "gss"}
+	rows := sqlmock.NewRows(columns).
+		AddRow(3, 2, 1)
+	c := PGConnectionEncryptionCollector{}
+	mock.ExpectQuery(sanitizeQuery(c.query(true))).WillReturnRows(rows)
+	cipherRows := sqlmock.NewRows([]string{"version", "cipher", "connections"}).
+		AddRow("TLSv1.2", "ECDHE-RSA-AES256-GCM-SHA384", 1).
+		AddRow("TLSv1.3", "TLS_AES_256_GCM_SHA384", 2)
+	mock.ExpectQuery(sanitizeQuery(c.cipherQuery())).WillReturnRows(cipherRows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		defer close(ch)
+
+		if err := c.Update(context.Background(), inst, ch); err != nil {
+			t.Errorf("Error calling PGConnectionEncryptionCollector.Update: %s", err)
+		}
+	}()
+
+	expected := []MetricResult{
+		{labels: labelMap{}, value: 3, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{}, value: 2, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{}, value: 1, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"version": "TLSv1.2", "cipher": "ECDHE-RSA-AES256-GCM-SHA384"}, value: 1, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"version": "TLSv1.3", "cipher": "TLS_AES_256_GCM_SHA384"}, value: 2, metricType: dto.MetricType_GAUGE},
+	}
+
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range expected {
+			m := readMetric(<-ch)
+			convey.So(expect, convey.ShouldResemble, m)
+		}
+	})
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
+
+func TestPGConnectionEncryptionCollectorQuery(t *testing.T) {
+	c := PGConnectionEncryptionCollector{excludeApplicationNames: []string{"pgbouncer"}}
+	for _, hasGSS := range []bool{false, true} {
+		for _, q := range []string{c.query(hasGSS), c.cipherQuery()} {
+			for _, want := range []string{"pid <> pg_backend_pid()", "NOT IN ('pgbouncer')", "client_port IS NOT NULL"} {
+				if !strings.Contains(q, want) {
+					t.Errorf("expected query to contain %q, got %s", want, q)
+				}
+			}
+		}
+	}
+}
@@ -60,15 +60,29 @@ var (
 			databaseSubsystem,
 			"connection_limit",
 		),
-		"Connection limit set for the database",
+		"Connection limit set for the database (-1 means no limit)",
+		[]string{"datname"}, nil,
+	)
+	pgDatabaseConnectionsCurrentDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(
+			namespace,
+			databaseSubsystem,
+			"connections_current",
+		),
+		"Number of backends currently connected to the database",
 		[]string{"datname"}, nil,
 	)
 
-	pgDatabaseQuery     = "SELECT pg_database.datname, pg_database.datconnlimit FROM pg_database;"
+	pgDatabaseQuery = `SELECT
+		pg_database.datname,
+		pg_database.datconnlimit,
+		(SELECT count(*) FROM pg_stat_activity WHERE pg_stat_activity.datid = pg_database.oid) AS connections
+	FROM pg_database;`
 	pgDatabaseSizeQuery = "SELECT pg_database_size($1)"
 )
 
-// Update implements Collector and exposes database size and connection limits.
+// Update implements Collector and exposes database size, connection limits
+// and current connections.
 // It is called by the Prometheus registry when collecting metrics.
 // The list of databases is retrieved from pg_database and filtered
 // by the excludeDatabase config parameter. The tradeoff here is that
@@ -79,9 +93,7 @@ var (
 func (c PGDatabaseCollector) Update(ctx context.Context, instance *Instance, ch chan<- prometheus.Metric) error {
 	db := instance.getDB()
 	// Query the list of databases
-	rows, err := db.QueryContext(ctx,
-		pgDatabaseQuery,
-	)
+	rows, err := queryWithOverride(ctx, db, databaseSubsystem, pgDatabaseQuery, 3)
 	if err != nil {
 		return err
 	}
@@ -91,8 +103,8 @@ func (c PGDatabaseCollector) Update(ctx context.Context, instance *Instance, ch
 
 	for rows.Next() {
 		var datname sql.NullString
-		var connLimit sql.NullInt64
-		if err := rows.Scan(&datname, &connLimit); err != nil {
+		var connLimit, connections sql.NullInt64
+		if err := rows.Scan(&datname, &connLimit, &connections); err != nil {
 			return err
 		}
 
@@ -117,6 +129,15 @@ func (c PGDatabaseCollector) Update(ctx context.Context, instance *Instance, ch
 			pgDatabaseConnectionLimitsDesc,
 			prometheus.GaugeValue, connLimitMetric, database,
 		)
+
+		connectionsMetric := 0.0
+		if connections.Valid {
+			connectionsMetric = float64(connections.Int64)
+		}
+		ch <- prometheus.MustNewConstMetric(
+			pgDatabaseConnectionsCurrentDesc,
+			prometheus.GaugeValue, connectionsMetric, database,
+		)
 	}
 
 	// Query the size of the databases
@@ -27,6 +27,9 @@ func init() {
 	registerCollector(databaseWraparoundSubsystem, defaultDisabled, NewPGDatabaseWraparoundCollector)
 }
 
+// PGDatabaseWraparoundCollector reports the transaction ID and multixact ID
+// ages of each database, plus the oldest relation multixact age in the
+// connected database, so both kinds of wraparound can be alerted on.
 type PGDatabaseWraparoundCollector struct {
 	log *slog.Logger
 }
@@ -48,6 +51,12 @@ var (
 		[]string{"datname"},
 		prometheus.Labels{},
 	)
+	databaseWraparoundRelminmxidAge = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, databaseWraparoundSubsystem, "oldest_relminmxid_age"),
+		"Largest mxid_age(relminmxid) of any table, materialized view or TOAST table in the database.",
+		[]string{"datname"},
+		prometheus.Labels{},
+	)
 
 	databaseWraparoundQuery = `
 	SELECT
@@ -59,12 +68,23 @@ var (
 	WHERE
 		d.datallowconn
 	`
+
+	// Only relations with storage have a valid relminmxid; mxid_age() of
+	// the invalid multixact ID is INT_MAX.
+	databaseWraparoundRelminmxidQuery = `
+	SELECT
+		current_database() AS datname,
+		max(mxid_age(c.relminmxid)) AS age_relminmxid
+	FROM
+		pg_catalog.pg_class c
+	WHERE
+		c.relkind IN ('r', 'm', 't')
+	`
 )
 
 func (c *PGDatabaseWraparoundCollector) Update(ctx context.Context, instance *Instance, ch chan<- prometheus.Metric) error {
 	db := instance.getDB()
-	rows, err := db.QueryContext(ctx,
-		databaseWraparoundQuery)
+	rows, err := queryWithOverride(ctx, db, databaseWraparoundSubsystem, databaseWraparoundQuery, 3)
 
 	if err != nil {
 		return err
@@ -110,5 +130,21 @@ func (c *PGDatabaseWraparoundCollector) Update(ctx context.Context, instance *In
 	if err := rows.Err(); err != nil {
 		return err
 	}
+
+	var datname sql.NullString
+	var ageRelminmxid sql.NullFloat64
+	err = db.QueryRowContext(ctx, databaseWraparoundRelminmxidQuery).Scan(&datname, &ageRelminmxid)
+	if err != nil {
+		return err
+	}
+	if !datname.Valid || !ageRelminmxid.Valid {
+		c.log.Debug("Skipping stat emission with NULL age_relminmxid")
+		return nil
+	}
+	ch <- prometheus.MustNewConstMetric(
+		databaseWraparoundRelminmxidAge,
+		prometheus.GaugeValue,
+		ageRelminmxid.Float64, datname.String,
+	)
 	return nil
 }
@@ -38,6 +38,8 @@ func TestPGDatabaseWraparoundCollector(t *testing.T) {
 		AddRow("newreddit", 87126426, 0)
 
 	mock.ExpectQuery(sanitizeQuery(databaseWraparoundQuery)).WillReturnRows(rows)
+	mock.ExpectQuery(sanitizeQuery(databaseWraparoundRelminmxidQuery)).WillReturnRows(
+		sqlmock.NewRows([]string{"datname", "age_relminmxid"}).AddRow("newreddit", 1250000))
 
 	ch := make(chan prometheus.Metric)
 	go func() {
@@ -51,6 +53,7 @@ func TestPGDatabaseWraparoundCollector(t *testing.T) {
 	expected := []MetricResult{
 		{labels: labelMap{"datname": "newreddit"}, value: 87126426, metricType: dto.MetricType_GAUGE},
 		{labels: labelMap{"datname": "newreddit"}, value: 0, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"datname": "newreddit"}, value: 1250000, metricType: dto.MetricType_GAUGE},
 	}
 	convey.Convey("Metrics comparison", t, func() {
 		for _, expect := range expected {
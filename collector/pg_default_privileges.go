@@ -0,0 +1,89 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const defaultPrivilegesSubsystem = "default_privileges"
+
+func init() {
+	registerCollector(defaultPrivilegesSubsystem, defaultDisabled, NewPGDefaultPrivilegesCollector)
+}
+
+// PGDefaultPrivilegesCollector reports roles that ALTER DEFAULT PRIVILEGES
+// grants write access to every future object of a kind, the equivalent of a
+// standing GRANT ALL ON ALL TABLES.
+type PGDefaultPrivilegesCollector struct {
+	log *slog.Logger
+}
+
+func NewPGDefaultPrivilegesCollector(config collectorConfig) (Collector, error) {
+	return &PGDefaultPrivilegesCollector{log: config.logger}, nil
+}
+
+var (
+	pgDefaultPrivilegesBroadDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "default_privileges", "broad"),
+		"Role is granted write privileges on new objects of the type by default (value is always 1)",
+		[]string{"datname", "role", "objtype"}, nil,
+	)
+
+	pgDefaultPrivilegesQuery = `SELECT DISTINCT
+		current_database() AS datname,
+		CASE WHEN a.grantee = 0 THEN 'PUBLIC' ELSE pg_catalog.pg_get_userbyid(a.grantee) END AS role,
+		CASE d.defaclobjtype
+			WHEN 'r' THEN 'table'
+			WHEN 'S' THEN 'sequence'
+			WHEN 'f' THEN 'function'
+			WHEN 'T' THEN 'type'
+			WHEN 'n' THEN 'schema'
+			ELSE d.defaclobjtype::text
+		END AS objtype
+	FROM pg_catalog.pg_default_acl d,
+		pg_catalog.aclexplode(d.defaclacl) a
+	WHERE a.privilege_type IN ('INSERT', 'UPDATE', 'DELETE', 'TRUNCATE', 'CREATE')
+	ORDER BY role, objtype`
+)
+
+func (c PGDefaultPrivilegesCollector) Update(ctx context.Context, instance *Instance, ch chan<- prometheus.Metric) error {
+	db := instance.getDB()
+	rows, err := queryWithOverride(ctx, db, defaultPrivilegesSubsystem, pgDefaultPrivilegesQuery, 3)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var datname, role, objtype sql.NullString
+		if err := rows.Scan(&datname, &role, &objtype); err != nil {
+			return err
+		}
+
+		if !datname.Valid || !role.Valid || !objtype.Valid {
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(
+			pgDefaultPrivilegesBroadDesc,
+			prometheus.GaugeValue, 1,
+			datname.String, role.String, objtype.String,
+		)
+	}
+	return rows.Err()
+}
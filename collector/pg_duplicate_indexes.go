@@ -0,0 +1,135 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+
+	"github.com/blang/semver/v4"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const duplicateIndexesSubsystem = "duplicate_indexes"
+
+func init() {
+	registerCollector(duplicateIndexesSubsystem, defaultDisabled, NewPGDuplicateIndexesCollector)
+}
+
+// PGDuplicateIndexesCollector reports indexes whose definition (columns,
+// operator classes, collations, expressions, predicate and uniqueness) is
+// identical to another index on the same table. Only one of them is ever
+// needed, so the size of each newer index is reported as wasted, against the
+// oldest index of that definition.
+type PGDuplicateIndexesCollector struct {
+	log *slog.Logger
+}
+
+func NewPGDuplicateIndexesCollector(config collectorConfig) (Collector, error) {
+	return &PGDuplicateIndexesCollector{log: config.logger}, nil
+}
+
+var (
+	pgDuplicateIndexDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "duplicate_index", "wasted_bytes"),
+		"Size of an index that duplicates the definition of an older index on the same table",
+		[]string{"datname", "schemaname", "relname", "indexrelname", "duplicate_of"}, nil,
+	)
+
+	pgDuplicateIndexesQuery     = duplicateIndexesQuery(false)
+	pgDuplicateIndexesQueryPG15 = duplicateIndexesQuery(true)
+)
+
+// duplicateIndexMatch returns the condition under which the indexes aliased
+// a and b, together with their pg_class rows ac and bc, have the same
+// definition. pg_node_tree and the vector types have no equality operator,
+// so the definitions are compared as text. pg_index.indnullsnotdistinct was
+// added in PostgreSQL 15.
+func duplicateIndexMatch(a, ac, b, bc string, nullsNotDistinct bool) string {
+	match := fmt.Sprintf(`%[1]s.indrelid = %[3]s.indrelid
+		AND %[2]s.relam = %[4]s.relam
+		AND %[1]s.indisunique = %[3]s.indisunique
+		AND %[1]s.indkey::text = %[3]s.indkey::text
+		AND %[1]s.indclass::text = %[3]s.indclass::text
+		AND %[1]s.indcollation::text = %[3]s.indcollation::text
+		AND coalesce(%[1]s.indexprs::text, '') = coalesce(%[3]s.indexprs::text, '')
+		AND coalesce(%[1]s.indpred::text, '') = coalesce(%[3]s.indpred::text, '')`, a, ac, b, bc)
+	if nullsNotDistinct {
+		match += fmt.Sprintf(`
+		AND %[1]s.indnullsnotdistinct = %[2]s.indnullsnotdistinct`, a, b)
+	}
+	return match
+}
+
+// duplicateIndexesQuery pairs every index with the oldest (lowest OID) index
+// of the same definition, so that of three identical indexes the second and
+// third are each reported once, as duplicates of the first.
+func duplicateIndexesQuery(nullsNotDistinct bool) string {
+	return fmt.Sprintf(`SELECT
+		current_database() AS datname,
+		n.nspname AS schemaname,
+		t.relname,
+		dup.relname AS indexrelname,
+		orig.relname AS duplicate_of,
+		pg_relation_size(i.indexrelid) AS wasted_bytes
+	FROM pg_catalog.pg_index i
+	JOIN pg_catalog.pg_class dup ON dup.oid = i.indexrelid
+	JOIN pg_catalog.pg_index o ON o.indexrelid < i.indexrelid
+	JOIN pg_catalog.pg_class orig ON orig.oid = o.indexrelid
+	JOIN pg_catalog.pg_class t ON t.oid = i.indrelid
+	JOIN pg_catalog.pg_namespace n ON n.oid = t.relnamespace
+	WHERE %s
+		AND n.nspname NOT IN ('pg_catalog', 'information_schema')
+		AND NOT EXISTS (SELECT 1
+		FROM pg_catalog.pg_index l
+		JOIN pg_catalog.pg_class lc ON lc.oid = l.indexrelid
+		WHERE l.indexrelid < o.indexrelid
+		AND %s)`,
+		duplicateIndexMatch("o", "orig", "i", "dup", nullsNotDistinct),
+		duplicateIndexMatch("l", "lc", "i", "dup", nullsNotDistinct))
+}
+
+func (c PGDuplicateIndexesCollector) Update(ctx context.Context, instance *Instance, ch chan<- prometheus.Metric) error {
+	query := pgDuplicateIndexesQuery
+	if instance.version.GTE(semver.MustParse("15.0.0")) {
+		query = pgDuplicateIndexesQueryPG15
+	}
+
+	db := instance.getDB()
+	rows, err := queryWithOverride(ctx, db, duplicateIndexesSubsystem, query, 6)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var datname, schemaname, relname, indexrelname, duplicateOf sql.NullString
+		var wastedBytes sql.NullInt64
+		if err := rows.Scan(&datname, &schemaname, &relname, &indexrelname, &duplicateOf, &wastedBytes); err != nil {
+			return err
+		}
+
+		if !datname.Valid || !schemaname.Valid || !relname.Valid || !indexrelname.Valid || !duplicateOf.Valid {
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(
+			pgDuplicateIndexDesc,
+			prometheus.GaugeValue, float64(wastedBytes.Int64),
+			datname.String, schemaname.String, relname.String, indexrelname.String, duplicateOf.String,
+		)
+	}
+	return rows.Err()
+}
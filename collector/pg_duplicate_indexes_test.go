@@ -0,0 +1,112 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package collector
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/blang/semver/v4"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+)
+
+func TestPGDuplicateIndexesCollector(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error opening a stub db connection: %s", err)
+	}
+	defer db.Close()
+
+	inst := &Instance{db: db}
+
+	columns := []string{"datname", "schemaname", "relname", "indexrelname", "duplicate_of", "wasted_bytes"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("postgres", "public", "orders", "orders_customer_id_idx1", "orders_customer_id_idx", 2408448).
+		AddRow("postgres", "billing", "invoices", "invoices_number_key", "invoices_number_idx", 16384)
+	mock.ExpectQuery(sanitizeQuery(pgDuplicateIndexesQuery)).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		defer close(ch)
+		c := PGDuplicateIndexesCollector{}
+
+		if err := c.Update(context.Background(), inst, ch); err != nil {
+			t.Errorf("Error calling PGDuplicateIndexesCollector.Update: %s", err)
+		}
+	}()
+
+	expected := []MetricResult{
+		{labels: labelMap{"datname": "postgres", "schemaname": "public", "relname": "orders", "indexrelname": "orders_customer_id_idx1", "duplicate_of": "orders_customer_id_idx"}, value: 2408448, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"datname": "postgres", "schemaname": "billing", "relname": "invoices", "indexrelname": "invoices_number_key", "duplicate_of": "invoices_number_idx"}, value: 16384, metricType: dto.MetricType_GAUGE},
+	}
+
+	convey.Convey("Metrics comparison", t, func() {
+		var metrics []MetricResult
+		for m := range ch {
+			metrics = append(metrics, readMetric(m))
+		}
+		convey.So(metrics, convey.ShouldResemble, expected)
+	})
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
+
+func TestPGDuplicateIndexesCollectorThreeIdenticalPG15(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error opening a stub db connection: %s", err)
+	}
+	defer db.Close()
+
+	inst := &Instance{db: db, version: semver.MustParse("15.0.0")}
+
+	// Of three identical indexes, the second and third are each reported
+	// once, against the oldest.
+	columns := []string{"datname", "schemaname", "relname", "indexrelname", "duplicate_of", "wasted_bytes"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("postgres", "public", "orders", "orders_customer_id_idx1", "orders_customer_id_idx", 8192).
+		AddRow("postgres", "public", "orders", "orders_customer_id_idx2", "orders_customer_id_idx", 8192)
+	mock.ExpectQuery(sanitizeQuery(pgDuplicateIndexesQueryPG15)).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		defer close(ch)
+		c := PGDuplicateIndexesCollector{}
+
+		if err := c.Update(context.Background(), inst, ch); err != nil {
+			t.Errorf("Error calling PGDuplicateIndexesCollector.Update: %s", err)
+		}
+	}()
+
+	expected := []MetricResult{
+		{labels: labelMap{"datname": "postgres", "schemaname": "public", "relname": "orders", "indexrelname": "orders_customer_id_idx1", "duplicate_of": "orders_customer_id_idx"}, value: 8192, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"datname": "postgres", "schemaname": "public", "relname": "orders", "indexrelname": "orders_customer_id_idx2", "duplicate_of": "orders_customer_id_idx"}, value: 8192, metricType: dto.MetricType_GAUGE},
+	}
+
+	convey.Convey("Metrics comparison", t, func() {
+		var metrics []MetricResult
+		for m := range ch {
+			metrics = append(metrics, readMetric(m))
+		}
+		convey.So(metrics, convey.ShouldResemble, expected)
+		convey.So(pgDuplicateIndexesQueryPG15, convey.ShouldContainSubstring, "indnullsnotdistinct")
+		convey.So(pgDuplicateIndexesQuery, convey.ShouldNotContainSubstring, "indnullsnotdistinct")
+	})
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
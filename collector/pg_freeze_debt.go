@@ -0,0 +1,100 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const freezeDebtSubsystem = "freeze_debt"
+
+var freezeDebtTopNFlag *uint = nil
+
+func init() {
+	registerCollector(freezeDebtSubsystem, defaultDisabled, NewPGFreezeDebtCollector)
+
+	freezeDebtTopNFlag = kingpin.Flag(
+		fmt.Sprint(collectorFlagPrefix, freezeDebtSubsystem, ".top-n"),
+		"Number of tables closest to a forced anti-wraparound vacuum to report.").
+		Default("20").
+		Uint()
+}
+
+// PGFreezeDebtCollector reports how close each table is to an aggressive
+// anti-wraparound autovacuum, as the ratio of age(relfrozenxid) to
+// autovacuum_freeze_max_age. Per-table autovacuum_freeze_max_age storage
+// parameters are not taken into account.
+type PGFreezeDebtCollector struct {
+	log  *slog.Logger
+	topN uint
+}
+
+func NewPGFreezeDebtCollector(config collectorConfig) (Collector, error) {
+	return &PGFreezeDebtCollector{
+		log:  config.logger,
+		topN: *freezeDebtTopNFlag,
+	}, nil
+}
+
+var (
+	pgTableXIDFreezeRatioDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "table", "xid_freeze_ratio"),
+		"Ratio of the table's relfrozenxid age to autovacuum_freeze_max_age; 1 forces an anti-wraparound vacuum",
+		[]string{"datname", "schemaname", "relname"}, nil,
+	)
+
+	pgFreezeDebtQuery = `SELECT
+		current_database() AS datname,
+		n.nspname AS schemaname,
+		c.relname,
+		age(c.relfrozenxid)::float / current_setting('autovacuum_freeze_max_age')::float AS freeze_ratio
+	FROM pg_catalog.pg_class c
+	JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+	WHERE c.relkind IN ('r', 'm', 't')
+	ORDER BY freeze_ratio DESC
+	LIMIT $1`
+)
+
+func (c PGFreezeDebtCollector) Update(ctx context.Context, instance *Instance, ch chan<- prometheus.Metric) error {
+	db := instance.getDB()
+	rows, err := queryWithOverride(ctx, db, freezeDebtSubsystem, pgFreezeDebtQuery, 4, c.topN)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var datname, schemaname, relname sql.NullString
+		var ratio sql.NullFloat64
+		if err := rows.Scan(&datname, &schemaname, &relname, &ratio); err != nil {
+			return err
+		}
+
+		if !datname.Valid || !schemaname.Valid || !relname.Valid || !ratio.Valid {
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(
+			pgTableXIDFreezeRatioDesc,
+			prometheus.GaugeValue, ratio.Float64,
+			datname.String, schemaname.String, relname.String,
+		)
+	}
+	return rows.Err()
+}
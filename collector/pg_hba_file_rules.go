@@ -0,0 +1,138 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/blang/semver/v4"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const hbaFileRulesSubsystem = "hba_file_rules"
+
+var hbaFileRulesRuleInfoFlag *bool = nil
+
+func init() {
+	// Disabled by default: pg_hba_file_rules can only be read by superusers
+	// unless access is granted explicitly.
+	registerCollector(hbaFileRulesSubsystem, defaultDisabled, NewPGHBAFileRulesCollector)
+
+	hbaFileRulesRuleInfoFlag = kingpin.Flag(
+		fmt.Sprint(collectorFlagPrefix, hbaFileRulesSubsystem, ".rule-info"),
+		"Also report the pg_hba.conf rules by connection type, database, user and authentication method.").
+		Default("false").
+		Bool()
+}
+
+// PGHBAFileRulesCollector reports on the contents of pg_hba.conf as currently
+// on disk: how many rules there are, how many failed to parse, and
+// optionally which authentication methods are in use, so that entries such
+// as trust can be caught.
+type PGHBAFileRulesCollector struct {
+	log      *slog.Logger
+	ruleInfo bool
+}
+
+func NewPGHBAFileRulesCollector(config collectorConfig) (Collector, error) {
+	return &PGHBAFileRulesCollector{
+		log:      config.logger,
+		ruleInfo: *hbaFileRulesRuleInfoFlag,
+	}, nil
+}
+
+var (
+	pgHBAFileRulesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "hba_file", "rules"),
+		"Number of rules in pg_hba.conf, including rules that failed to parse",
+		[]string{}, nil,
+	)
+	pgHBAFileRuleErrorsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "hba_file", "rule_errors"),
+		"Number of rules in pg_hba.conf that failed to parse",
+		[]string{}, nil,
+	)
+	pgHBAFileRuleDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "hba_file", "rule"),
+		"Number of pg_hba.conf rules with this connection type, database, user and authentication method",
+		[]string{"type", "database", "user_name", "auth_method"}, nil,
+	)
+
+	pgHBAFileRulesQuery = `SELECT
+		count(*) AS rules,
+		count(error) AS errors
+	FROM pg_catalog.pg_hba_file_rules`
+
+	// Rules that differ only by address or options collapse into one series.
+	pgHBAFileRuleInfoQuery = `SELECT
+		type,
+		array_to_string(database, ',') AS database,
+		array_to_string(user_name, ',') AS user_name,
+		auth_method,
+		count(*) AS rules
+	FROM pg_catalog.pg_hba_file_rules
+	WHERE error IS NULL
+	GROUP BY 1, 2, 3, 4
+	ORDER BY 1, 2, 3, 4`
+)
+
+func (c PGHBAFileRulesCollector) Update(ctx context.Context, instance *Instance, ch chan<- prometheus.Metric) error {
+	if instance.version.LT(semver.MustParse("10.0.0")) {
+		return skipUnsupportedVersion(c.log, "pg_hba_file_rules is not available before PostgreSQL 10")
+	}
+
+	db := instance.getDB()
+	var rules, ruleErrors sql.NullInt64
+	if err := scanRowWithOverride(ctx, db, hbaFileRulesSubsystem, pgHBAFileRulesQuery, nil, &rules, &ruleErrors); err != nil {
+		return err
+	}
+	ch <- prometheus.MustNewConstMetric(
+		pgHBAFileRulesDesc,
+		prometheus.GaugeValue, float64(rules.Int64),
+	)
+	ch <- prometheus.MustNewConstMetric(
+		pgHBAFileRuleErrorsDesc,
+		prometheus.GaugeValue, float64(ruleErrors.Int64),
+	)
+
+	if !c.ruleInfo {
+		return nil
+	}
+	rows, err := db.QueryContext(ctx, pgHBAFileRuleInfoQuery)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var ruleType, database, userName, authMethod sql.NullString
+		var count sql.NullInt64
+		if err := rows.Scan(&ruleType, &database, &userName, &authMethod, &count); err != nil {
+			return err
+		}
+		if !ruleType.Valid || !authMethod.Valid {
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(
+			pgHBAFileRuleDesc,
+			prometheus.GaugeValue, float64(count.Int64),
+			ruleType.String, database.String, userName.String, authMethod.String,
+		)
+	}
+	return rows.Err()
+}
@@ -0,0 +1,114 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const idleConnectionsSubsystem = "idle_connections"
+
+func init() {
+	registerCollector(idleConnectionsSubsystem, defaultDisabled, NewPGIdleConnectionsCollector)
+}
+
+// PGIdleConnectionsCollector reports how many connections are idle in each
+// database and how long the longest of them has been idle, to help size
+// connection pools. The exporter's own backend is not counted.
+type PGIdleConnectionsCollector struct {
+	log                     *slog.Logger
+	excludeApplicationNames []string
+	skipOnStandby           bool
+	roleLabel               bool
+}
+
+func NewPGIdleConnectionsCollector(config collectorConfig) (Collector, error) {
+	return &PGIdleConnectionsCollector{
+		log:                     config.logger,
+		excludeApplicationNames: excludeApplicationNames(),
+		skipOnStandby:           activitySkipOnStandby(),
+		roleLabel:               activityRoleLabel(),
+	}, nil
+}
+
+var (
+	pgIdleConnectionsDesc = newActivityDesc(
+		prometheus.BuildFQName(namespace, "", "idle_connections"),
+		"Number of connections in the idle state",
+		[]string{"datname"},
+	)
+	pgIdleConnectionOldestSecondsDesc = newActivityDesc(
+		prometheus.BuildFQName(namespace, "", "idle_connection_oldest_seconds"),
+		"Seconds since the longest idle connection last changed state (0 when none are idle)",
+		[]string{},
+	)
+
+	pgIdleConnectionsQueryBase = `SELECT
+		datname,
+		count(*) AS connections,
+		MAX(EXTRACT(EPOCH FROM (clock_timestamp() - state_change))) AS oldest_seconds
+	FROM pg_catalog.pg_stat_activity`
+)
+
+func (c PGIdleConnectionsCollector) query() string {
+	q := newQueryBuilder(pgIdleConnectionsQueryBase).
+		where("state = 'idle'").
+		where("datname IS NOT NULL").
+		where(excludeSelfCondition(c.excludeApplicationNames))
+	return terminateQuery(q.subquery() + "\nGROUP BY datname")
+}
+
+func (c PGIdleConnectionsCollector) Update(ctx context.Context, instance *Instance, ch chan<- prometheus.Metric) error {
+	role, skip, err := activityRole(ctx, instance, c.skipOnStandby, c.roleLabel, c.log)
+	if err != nil || skip {
+		return err
+	}
+	db := instance.getDB()
+	rows, err := queryWithOverride(ctx, db, idleConnectionsSubsystem, c.query(), 3)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	oldest := 0.0
+	for rows.Next() {
+		var datname sql.NullString
+		var connections sql.NullInt64
+		var oldestSeconds sql.NullFloat64
+		if err := rows.Scan(&datname, &connections, &oldestSeconds); err != nil {
+			return err
+		}
+		if !datname.Valid {
+			continue
+		}
+		if oldestSeconds.Valid && oldestSeconds.Float64 > oldest {
+			oldest = oldestSeconds.Float64
+		}
+		ch <- pgIdleConnectionsDesc.mustNewConstMetric(
+			role, prometheus.GaugeValue, float64(connections.Int64), datname.String,
+		)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	ch <- pgIdleConnectionOldestSecondsDesc.mustNewConstMetric(
+		role, prometheus.GaugeValue, oldest,
+	)
+	return nil
+}
@@ -0,0 +1,79 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package collector
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+)
+
+func TestPGIdleConnectionsCollector(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error opening a stub db connection: %s", err)
+	}
+	defer db.Close()
+
+	inst := &Instance{db: db}
+
+	columns := []string{"datname", "connections", "oldest_seconds"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("orders", 42, 310.5).
+		AddRow("analytics", 3, 7200.25)
+	mock.ExpectQuery(sanitizeQuery(PGIdleConnectionsCollector{}.query())).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		defer close(ch)
+		c := PGIdleConnectionsCollector{}
+
+		if err := c.Update(context.Background(), inst, ch); err != nil {
+			t.Errorf("Error calling PGIdleConnectionsCollector.Update: %s", err)
+		}
+	}()
+
+	expected := []MetricResult{
+		{labels: labelMap{"datname": "orders"}, value: 42, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"datname": "analytics"}, value: 3, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{}, value: 7200.25, metricType: dto.MetricType_GAUGE},
+	}
+
+	convey.Convey("Metrics comparison", t, func() {
+		var metrics []MetricResult
+		for m := range ch {
+			metrics = append(metrics, readMetric(m))
+		}
+		convey.So(metrics, convey.ShouldResemble, expected)
+	})
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
+
+func TestPGIdleConnectionsCollectorQuery(t *testing.T) {
+	q := PGIdleConnectionsCollector{excludeApplicationNames: []string{"pgbouncer"}}.query()
+	for _, want := range []string{"pid <> pg_backend_pid()", "NOT IN ('pgbouncer')"} {
+		if !strings.Contains(q, want) {
+			t.Errorf("expected query to contain %q, got %s", want, q)
+		}
+	}
+	if !strings.HasSuffix(q, "GROUP BY datname;") {
+		t.Errorf("expected query to end with the GROUP BY clause, got %s", q)
+	}
+}
@@ -0,0 +1,191 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const indexBloatSubsystem = "index_bloat"
+
+var (
+	indexBloatMinSizeFlag *uint64 = nil
+	indexBloatTopNFlag    *uint   = nil
+)
+
+func init() {
+	// Like table_bloat, the estimation joins pg_stats for every indexed
+	// column and is too expensive to run by default on large schemas.
+	registerCollector(indexBloatSubsystem, defaultDisabled, NewPGIndexBloatCollector)
+
+	indexBloatMinSizeFlag = kingpin.Flag(
+		fmt.Sprint(collectorFlagPrefix, indexBloatSubsystem, ".min-size-bytes"),
+		"Only estimate bloat for indexes at least this large, in bytes.").
+		Default("10485760").
+		Uint64()
+	indexBloatTopNFlag = kingpin.Flag(
+		fmt.Sprint(collectorFlagPrefix, indexBloatSubsystem, ".top-n"),
+		"Number of indexes with the most estimated bloat to report.").
+		Default("100").
+		Uint()
+}
+
+// PGIndexBloatCollector estimates the space wasted in each btree index by
+// comparing its actual size with the number of pages needed to hold its
+// tuples at the index fillfactor, derived from the column statistics of the
+// indexed columns.
+type PGIndexBloatCollector struct {
+	log     *slog.Logger
+	minSize uint64
+	topN    uint
+}
+
+func NewPGIndexBloatCollector(config collectorConfig) (Collector, error) {
+	return &PGIndexBloatCollector{
+		log:     config.logger,
+		minSize: *indexBloatMinSizeFlag,
+		topN:    *indexBloatTopNFlag,
+	}, nil
+}
+
+var (
+	pgIndexBloatBytesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, indexBloatSubsystem, "bytes"),
+		"Estimated bytes of the btree index that are not used by live index tuples",
+		[]string{"datname", "schemaname", "relname", "indexrelname"}, nil,
+	)
+	pgIndexBloatRatioDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, indexBloatSubsystem, "ratio"),
+		"Estimated fraction of the btree index that is not used by live index tuples",
+		[]string{"datname", "schemaname", "relname", "indexrelname"}, nil,
+	)
+
+	// pgIndexBloatQuery is the btree estimation from pgsql-bloat-estimation,
+	// assuming 8-byte alignment. Expression index columns are looked up in
+	// pg_stats under the index name, as that is where ANALYZE stores them.
+	pgIndexBloatQuery = `SELECT
+		current_database() AS datname,
+		nspname AS schemaname,
+		tblname AS relname,
+		idxname AS indexrelname,
+		CASE WHEN relpages > est_pages_ff
+			THEN bs * (relpages - est_pages_ff) ELSE 0 END AS bloat_bytes,
+		CASE WHEN relpages > est_pages_ff
+			THEN (relpages - est_pages_ff)::float / relpages ELSE 0 END AS bloat_ratio
+	FROM (
+		SELECT
+			coalesce(1 + ceil(reltuples / floor((bs - pageopqdata - pagehdr) * fillfactor / (100 * (4 + nulldatahdrwidth)::float))), 0) AS est_pages_ff,
+			bs, nspname, tblname, idxname, relpages, is_na
+		FROM (
+			SELECT
+				bs, nspname, tblname, idxname, reltuples, relpages, fillfactor, pagehdr, pageopqdata, is_na,
+				(index_tuple_hdr_bm
+					+ maxalign - CASE WHEN index_tuple_hdr_bm % maxalign = 0 THEN maxalign ELSE index_tuple_hdr_bm % maxalign END
+					+ nulldatawidth
+					+ maxalign - CASE WHEN nulldatawidth = 0 THEN 0
+						WHEN nulldatawidth::integer % maxalign = 0 THEN maxalign
+						ELSE nulldatawidth::integer % maxalign END
+				)::numeric AS nulldatahdrwidth
+			FROM (
+				SELECT
+					n.nspname, i.tblname, i.idxname, i.reltuples, i.relpages, i.fillfactor,
+					current_setting('block_size')::numeric AS bs,
+					8 AS maxalign,
+					24 AS pagehdr,
+					16 AS pageopqdata,
+					CASE WHEN max(coalesce(s.null_frac, 0)) = 0 THEN 8 ELSE 8 + ((32 + 8 - 1) / 8) END AS index_tuple_hdr_bm,
+					sum((1 - coalesce(s.null_frac, 0)) * coalesce(s.avg_width, 1024)) AS nulldatawidth,
+					bool_or(i.atttypid = 'pg_catalog.name'::regtype) AS is_na
+				FROM (
+					SELECT
+						ct.relname AS tblname, ct.relnamespace, ic.idxname, ic.idxoid, ic.reltuples, ic.relpages, ic.fillfactor,
+						coalesce(a1.attname, a2.attname) AS attname,
+						coalesce(a1.atttypid, a2.atttypid) AS atttypid,
+						CASE WHEN a1.attnum IS NULL THEN ic.idxname ELSE ct.relname END AS attrelname
+					FROM (
+						SELECT
+							ci.relname AS idxname, ci.reltuples, ci.relpages, i.indrelid AS tbloid, i.indexrelid AS idxoid,
+							coalesce(substring(array_to_string(ci.reloptions, ' ') FROM 'fillfactor=([0-9]+)')::smallint, 90) AS fillfactor,
+							string_to_array(textin(int2vectorout(i.indkey)), ' ')::int[] AS indkey,
+							generate_series(1, i.indnatts) AS attpos
+						FROM pg_catalog.pg_index i
+						JOIN pg_catalog.pg_class ci ON ci.oid = i.indexrelid
+						JOIN pg_catalog.pg_am am ON am.oid = ci.relam
+						WHERE am.amname = 'btree'
+							AND ci.relpages > 0
+					) AS ic
+					JOIN pg_catalog.pg_class ct ON ct.oid = ic.tbloid
+					LEFT JOIN pg_catalog.pg_attribute a1 ON ic.indkey[ic.attpos] <> 0
+						AND a1.attrelid = ic.tbloid
+						AND a1.attnum = ic.indkey[ic.attpos]
+					LEFT JOIN pg_catalog.pg_attribute a2 ON ic.indkey[ic.attpos] = 0
+						AND a2.attrelid = ic.idxoid
+						AND a2.attnum = ic.attpos
+				) AS i
+				JOIN pg_catalog.pg_namespace n ON n.oid = i.relnamespace
+				JOIN pg_catalog.pg_stats s ON s.schemaname = n.nspname
+					AND s.tablename = i.attrelname
+					AND s.attname = i.attname
+				WHERE n.nspname NOT IN ('pg_catalog', 'information_schema')
+				GROUP BY n.nspname, i.tblname, i.idxname, i.idxoid, i.reltuples, i.relpages, i.fillfactor
+			) AS rows_data_stats
+		) AS rows_hdr_pdg_stats
+	) AS relation_stats
+	WHERE NOT is_na
+		AND relpages * bs >= $1
+	ORDER BY bloat_bytes DESC
+	LIMIT $2`
+)
+
+func (c PGIndexBloatCollector) Update(ctx context.Context, instance *Instance, ch chan<- prometheus.Metric) error {
+	db := instance.getDB()
+	rows, err := queryWithOverride(ctx, db, indexBloatSubsystem, pgIndexBloatQuery, 6, c.minSize, c.topN)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var datname, schemaname, relname, indexrelname sql.NullString
+		var bloatBytes, bloatRatio sql.NullFloat64
+		if err := rows.Scan(&datname, &schemaname, &relname, &indexrelname, &bloatBytes, &bloatRatio); err != nil {
+			return err
+		}
+
+		if !datname.Valid || !schemaname.Valid || !relname.Valid || !indexrelname.Valid {
+			continue
+		}
+		labels := []string{datname.String, schemaname.String, relname.String, indexrelname.String}
+
+		if bloatBytes.Valid {
+			ch <- prometheus.MustNewConstMetric(
+				pgIndexBloatBytesDesc,
+				prometheus.GaugeValue, bloatBytes.Float64, labels...,
+			)
+		}
+		if bloatRatio.Valid {
+			ch <- prometheus.MustNewConstMetric(
+				pgIndexBloatRatioDesc,
+				prometheus.GaugeValue, bloatRatio.Float64, labels...,
+			)
+		}
+	}
+	return rows.Err()
+}
@@ -0,0 +1,67 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package collector
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+)
+
+func TestPGIndexBloatCollector(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error opening a stub db connection: %s", err)
+	}
+	defer db.Close()
+
+	inst := &Instance{db: db}
+
+	columns := []string{"datname", "schemaname", "relname", "indexrelname", "bloat_bytes", "bloat_ratio"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("postgres", "public", "events", "events_created_at_idx", 536870912, 0.25).
+		AddRow("postgres", "public", "users", "users_pkey", 0, 0)
+	mock.ExpectQuery(sanitizeQuery(pgIndexBloatQuery)).WithArgs(10485760, 100).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		defer close(ch)
+		c := PGIndexBloatCollector{minSize: 10485760, topN: 100}
+
+		if err := c.Update(context.Background(), inst, ch); err != nil {
+			t.Errorf("Error calling PGIndexBloatCollector.Update: %s", err)
+		}
+	}()
+
+	expected := []MetricResult{
+		{labels: labelMap{"datname": "postgres", "schemaname": "public", "relname": "events", "indexrelname": "events_created_at_idx"}, value: 536870912, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"datname": "postgres", "schemaname": "public", "relname": "events", "indexrelname": "events_created_at_idx"}, value: 0.25, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"datname": "postgres", "schemaname": "public", "relname": "users", "indexrelname": "users_pkey"}, value: 0, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"datname": "postgres", "schemaname": "public", "relname": "users", "indexrelname": "users_pkey"}, value: 0, metricType: dto.MetricType_GAUGE},
+	}
+
+	convey.Convey("Metrics comparison", t, func() {
+		var metrics []MetricResult
+		for m := range ch {
+			metrics = append(metrics, readMetric(m))
+		}
+		convey.So(metrics, convey.ShouldResemble, expected)
+	})
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
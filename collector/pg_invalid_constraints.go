@@ -0,0 +1,81 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const invalidConstraintsSubsystem = "invalid_constraints"
+
+func init() {
+	registerCollector(invalidConstraintsSubsystem, defaultDisabled, NewPGInvalidConstraintsCollector)
+}
+
+// PGInvalidConstraintsCollector reports constraints that were added with
+// NOT VALID and never validated, so existing rows were never checked.
+type PGInvalidConstraintsCollector struct {
+	log *slog.Logger
+}
+
+func NewPGInvalidConstraintsCollector(config collectorConfig) (Collector, error) {
+	return &PGInvalidConstraintsCollector{log: config.logger}, nil
+}
+
+var (
+	pgInvalidConstraintDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "invalid_constraint"),
+		"Constraint is marked NOT VALID and has not been validated (value is always 1)",
+		[]string{"datname", "schemaname", "conname", "contype"}, nil,
+	)
+
+	pgInvalidConstraintsQuery = `SELECT
+		current_database() AS datname,
+		n.nspname AS schemaname,
+		c.conname,
+		c.contype::text
+	FROM pg_catalog.pg_constraint c
+	JOIN pg_catalog.pg_namespace n ON n.oid = c.connamespace
+	WHERE NOT c.convalidated`
+)
+
+func (c PGInvalidConstraintsCollector) Update(ctx context.Context, instance *Instance, ch chan<- prometheus.Metric) error {
+	db := instance.getDB()
+	rows, err := queryWithOverride(ctx, db, invalidConstraintsSubsystem, pgInvalidConstraintsQuery, 4)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var datname, schemaname, conname, contype sql.NullString
+		if err := rows.Scan(&datname, &schemaname, &conname, &contype); err != nil {
+			return err
+		}
+
+		if !datname.Valid || !schemaname.Valid || !conname.Valid || !contype.Valid {
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(
+			pgInvalidConstraintDesc,
+			prometheus.GaugeValue, 1,
+			datname.String, schemaname.String, conname.String, contype.String,
+		)
+	}
+	return rows.Err()
+}
@@ -0,0 +1,98 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const invalidIndexesSubsystem = "invalid_indexes"
+
+func init() {
+	registerCollector(invalidIndexesSubsystem, defaultDisabled, NewPGInvalidIndexesCollector)
+}
+
+// PGInvalidIndexesCollector reports indexes that the planner will not use,
+// typically left behind by a failed CREATE INDEX CONCURRENTLY.
+type PGInvalidIndexesCollector struct {
+	log *slog.Logger
+}
+
+func NewPGInvalidIndexesCollector(config collectorConfig) (Collector, error) {
+	return &PGInvalidIndexesCollector{log: config.logger}, nil
+}
+
+var (
+	pgInvalidIndexDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "invalid_index"),
+		"Index is marked invalid and is not used by the planner (value is always 1)",
+		[]string{"datname", "schemaname", "indexrelname"}, nil,
+	)
+	pgIndexNotReadyDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "index_not_ready"),
+		"Index is not ready for inserts (value is always 1)",
+		[]string{"datname", "schemaname", "indexrelname"}, nil,
+	)
+
+	pgInvalidIndexesQuery = `SELECT
+		current_database() AS datname,
+		n.nspname AS schemaname,
+		c.relname AS indexrelname,
+		i.indisvalid,
+		i.indisready
+	FROM pg_catalog.pg_index i
+	JOIN pg_catalog.pg_class c ON c.oid = i.indexrelid
+	JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+	WHERE NOT i.indisvalid OR NOT i.indisready`
+)
+
+func (c PGInvalidIndexesCollector) Update(ctx context.Context, instance *Instance, ch chan<- prometheus.Metric) error {
+	db := instance.getDB()
+	rows, err := queryWithOverride(ctx, db, invalidIndexesSubsystem, pgInvalidIndexesQuery, 5)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var datname, schemaname, indexrelname sql.NullString
+		var isValid, isReady sql.NullBool
+		if err := rows.Scan(&datname, &schemaname, &indexrelname, &isValid, &isReady); err != nil {
+			return err
+		}
+
+		if !datname.Valid || !schemaname.Valid || !indexrelname.Valid {
+			continue
+		}
+		labels := []string{datname.String, schemaname.String, indexrelname.String}
+
+		if isValid.Valid && !isValid.Bool {
+			ch <- prometheus.MustNewConstMetric(
+				pgInvalidIndexDesc,
+				prometheus.GaugeValue, 1, labels...,
+			)
+		}
+		if isReady.Valid && !isReady.Bool {
+			ch <- prometheus.MustNewConstMetric(
+				pgIndexNotReadyDesc,
+				prometheus.GaugeValue, 1, labels...,
+			)
+		}
+	}
+	return rows.Err()
+}
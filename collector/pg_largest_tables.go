@@ -0,0 +1,120 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const largestTablesSubsystem = "largest_tables"
+
+var largestTablesTopNFlag *uint = nil
+
+func init() {
+	registerCollector(largestTablesSubsystem, defaultDisabled, NewPGLargestTablesCollector)
+
+	largestTablesTopNFlag = kingpin.Flag(
+		fmt.Sprint(collectorFlagPrefix, largestTablesSubsystem, ".top-n"),
+		"Number of largest tables to report per database.").
+		Default("20").
+		Uint()
+}
+
+type PGLargestTablesCollector struct {
+	log  *slog.Logger
+	topN uint
+}
+
+func NewPGLargestTablesCollector(config collectorConfig) (Collector, error) {
+	return &PGLargestTablesCollector{
+		log:  config.logger,
+		topN: *largestTablesTopNFlag,
+	}, nil
+}
+
+var (
+	pgTableTotalBytesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "table", "total_bytes"),
+		"Total disk space used by the table, including indexes and TOAST data",
+		[]string{"datname", "schemaname", "relname"}, nil,
+	)
+	pgTableSizeLimitRatioDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "table", "size_limit_ratio"),
+		"Ratio of the table's main fork size to the 32TB single relation limit",
+		[]string{"datname", "schemaname", "relname"}, nil,
+	)
+
+	pgLargestTablesQuery = `SELECT
+		current_database() AS datname,
+		n.nspname AS schemaname,
+		c.relname,
+		pg_total_relation_size(c.oid) AS total_bytes,
+		pg_relation_size(c.oid) AS relation_bytes
+	FROM pg_catalog.pg_class c
+	JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+	WHERE c.relkind IN ('r', 'm')
+		AND n.nspname NOT IN ('pg_catalog', 'information_schema')
+		AND n.nspname !~ '^pg_toast'
+	ORDER BY total_bytes DESC
+	LIMIT $1`
+)
+
+// relationSizeLimitBytes is the maximum size of a single relation with the
+// default 8kB block size.
+const relationSizeLimitBytes = 32 * 1024 * 1024 * 1024 * 1024
+
+func (c PGLargestTablesCollector) Update(ctx context.Context, instance *Instance, ch chan<- prometheus.Metric) error {
+	db := instance.getDB()
+	rows, err := queryWithOverride(ctx, db, largestTablesSubsystem, pgLargestTablesQuery, 5, c.topN)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var datname, schemaname, relname sql.NullString
+		var totalBytes, relationBytes sql.NullFloat64
+		if err := rows.Scan(&datname, &schemaname, &relname, &totalBytes, &relationBytes); err != nil {
+			return err
+		}
+
+		if !datname.Valid || !schemaname.Valid || !relname.Valid {
+			continue
+		}
+
+		totalBytesMetric := 0.0
+		if totalBytes.Valid {
+			totalBytesMetric = totalBytes.Float64
+		}
+		ch <- prometheus.MustNewConstMetric(
+			pgTableTotalBytesDesc,
+			prometheus.GaugeValue, totalBytesMetric,
+			datname.String, schemaname.String, relname.String,
+		)
+		if relationBytes.Valid {
+			ch <- prometheus.MustNewConstMetric(
+				pgTableSizeLimitRatioDesc,
+				prometheus.GaugeValue, relationBytes.Float64/relationSizeLimitBytes,
+				datname.String, schemaname.String, relname.String,
+			)
+		}
+	}
+	return rows.Err()
+}
@@ -0,0 +1,110 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package collector
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+)
+
+func TestPGLargestTablesCollector(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error opening a stub db connection: %s", err)
+	}
+	defer db.Close()
+
+	inst := &Instance{db: db}
+
+	columns := []string{"datname", "schemaname", "relname", "total_bytes", "relation_bytes"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("postgres", "public", "events", 4096000, 4000000).
+		AddRow("postgres", "public", "orders", 2048000, 2000000)
+	mock.ExpectQuery(sanitizeQuery(pgLargestTablesQuery)).WithArgs(2).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		defer close(ch)
+		c := PGLargestTablesCollector{topN: 2}
+
+		if err := c.Update(context.Background(), inst, ch); err != nil {
+			t.Errorf("Error calling PGLargestTablesCollector.Update: %s", err)
+		}
+	}()
+
+	var metrics []MetricResult
+	for m := range ch {
+		metrics = append(metrics, readMetric(m))
+	}
+
+	expected := []MetricResult{
+		{labels: labelMap{"datname": "postgres", "schemaname": "public", "relname": "events"}, value: 4096000, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"datname": "postgres", "schemaname": "public", "relname": "events"}, value: 4000000.0 / relationSizeLimitBytes, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"datname": "postgres", "schemaname": "public", "relname": "orders"}, value: 2048000, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"datname": "postgres", "schemaname": "public", "relname": "orders"}, value: 2000000.0 / relationSizeLimitBytes, metricType: dto.MetricType_GAUGE},
+	}
+
+	convey.Convey("Metrics comparison", t, func() {
+		convey.So(metrics, convey.ShouldResemble, expected)
+	})
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
+
+func TestPGLargestTablesCollectorSizeLimitRatio(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error opening a stub db connection: %s", err)
+	}
+	defer db.Close()
+
+	inst := &Instance{db: db}
+
+	// A 24TB heap is three quarters of the way to the relation size limit.
+	const tb = 1024 * 1024 * 1024 * 1024
+	columns := []string{"datname", "schemaname", "relname", "total_bytes", "relation_bytes"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("warehouse", "public", "events", float64(26*tb), float64(24*tb))
+	mock.ExpectQuery(sanitizeQuery(pgLargestTablesQuery)).WithArgs(1).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		defer close(ch)
+		c := PGLargestTablesCollector{topN: 1}
+
+		if err := c.Update(context.Background(), inst, ch); err != nil {
+			t.Errorf("Error calling PGLargestTablesCollector.Update: %s", err)
+		}
+	}()
+
+	expected := []MetricResult{
+		{labels: labelMap{"datname": "warehouse", "schemaname": "public", "relname": "events"}, value: float64(26 * tb), metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"datname": "warehouse", "schemaname": "public", "relname": "events"}, value: 0.75, metricType: dto.MetricType_GAUGE},
+	}
+
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range expected {
+			m := readMetric(<-ch)
+			convey.So(expect, convey.ShouldResemble, m)
+		}
+	})
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
@@ -0,0 +1,243 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/blang/semver/v4"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const lockChainsSubsystem = "lock_chains"
+
+var lockChainsBlockerLabelsFlag *bool = nil
+
+func init() {
+	// Disabled by default: pg_blocking_pids() takes the lock manager's
+	// partition locks for every waiting backend.
+	registerCollector(lockChainsSubsystem, defaultDisabled, NewPGLockChainsCollector)
+
+	lockChainsBlockerLabelsFlag = kingpin.Flag(
+		fmt.Sprint(collectorFlagPrefix, lockChainsSubsystem, ".blocker-labels"),
+		"Also report the number of blocking sessions broken down by the blockers' application_name and usename.").
+		Default("false").
+		Bool()
+}
+
+// PGLockChainsCollector builds the lock wait-for graph and reports how deep
+// the wait chains are. A chain is rooted at a session that blocks others
+// while not waiting itself; its depth counts every session in the chain,
+// including the root. It also reports how many sessions are blocked, how many
+// distinct sessions block them and how long the longest-blocked session has
+// been waiting for its lock.
+type PGLockChainsCollector struct {
+	log           *slog.Logger
+	blockerLabels bool
+}
+
+func NewPGLockChainsCollector(config collectorConfig) (Collector, error) {
+	return &PGLockChainsCollector{
+		log:           config.logger,
+		blockerLabels: *lockChainsBlockerLabelsFlag,
+	}, nil
+}
+
+var (
+	pgLockWaitChainMaxDepthDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "lock_wait_chain", "max_depth"),
+		"Number of sessions in the longest lock wait chain",
+		[]string{}, nil,
+	)
+	pgLockWaitChainCountDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "lock_wait_chain", "count"),
+		"Number of lock wait chains, counted by the non-waiting sessions at their heads",
+		[]string{}, nil,
+	)
+	pgLockWaitChainLongestSecondsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "lock_wait_chain", "longest_seconds"),
+		"Seconds the longest-blocked session has been waiting for its lock, or 0 if no session is blocked. Before PostgreSQL 14 this is approximated by the time since its query started",
+		[]string{}, nil,
+	)
+	pgLockBlockedBackendsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "lock", "blocked_backends"),
+		"Number of sessions waiting on a lock held by another session",
+		[]string{}, nil,
+	)
+	pgLockBlockingBackendsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "lock", "blocking_backends"),
+		"Number of distinct sessions blocking at least one other session",
+		[]string{}, nil,
+	)
+	pgLockBlockingBackendsByClientDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "lock", "blocking_backends_by_client"),
+		"Number of distinct sessions blocking at least one other session, by the blockers' client",
+		[]string{"application_name", "usename"}, nil,
+	)
+
+	// pgLockChainsQueryTemplate takes the start of the wait. pg_locks.waitstart
+	// was added in PostgreSQL 14; before that the start of the waiting query
+	// is used, which overstates the wait of a query that ran for a while
+	// before it blocked.
+	pgLockChainsQueryTemplate = `SELECT DISTINCT
+		a.pid,
+		blocking.pid AS blocking_pid,
+		EXTRACT(EPOCH FROM now() - %s) AS waiting_seconds,
+		COALESCE(b.application_name, '') AS blocking_application_name,
+		COALESCE(b.usename, '') AS blocking_usename
+	FROM pg_catalog.pg_stat_activity a
+	JOIN pg_catalog.pg_locks l ON l.pid = a.pid AND NOT l.granted
+	CROSS JOIN LATERAL unnest(pg_catalog.pg_blocking_pids(a.pid)) AS blocking(pid)
+	LEFT JOIN pg_catalog.pg_stat_activity b ON b.pid = blocking.pid`
+
+	pgLockChainsQuery     = fmt.Sprintf(pgLockChainsQueryTemplate, "a.query_start")
+	pgLockChainsQueryPG14 = fmt.Sprintf(pgLockChainsQueryTemplate, "l.waitstart")
+)
+
+// lockBlocker identifies the client of a blocking session.
+type lockBlocker struct {
+	applicationName string
+	usename         string
+}
+
+func (c PGLockChainsCollector) Update(ctx context.Context, instance *Instance, ch chan<- prometheus.Metric) error {
+	if instance.version.LT(semver.MustParse("9.6.0")) {
+		return skipUnsupportedVersion(c.log, "pg_blocking_pids() is not available before PostgreSQL 9.6")
+	}
+
+	query := pgLockChainsQuery
+	if instance.version.GTE(semver.MustParse("14.0.0")) {
+		query = pgLockChainsQueryPG14
+	}
+
+	db := instance.getDB()
+	rows, err := queryWithOverride(ctx, db, lockChainsSubsystem, query, 5)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	blockers := make(map[int64][]int64)
+	blockingClients := make(map[int64]lockBlocker)
+	longestWait := 0.0
+	for rows.Next() {
+		var pid, blockingPid sql.NullInt64
+		var waitingSeconds sql.NullFloat64
+		var applicationName, usename sql.NullString
+		if err := rows.Scan(&pid, &blockingPid, &waitingSeconds, &applicationName, &usename); err != nil {
+			return err
+		}
+		if !pid.Valid || !blockingPid.Valid {
+			continue
+		}
+		blockers[pid.Int64] = append(blockers[pid.Int64], blockingPid.Int64)
+		blockingClients[blockingPid.Int64] = lockBlocker{applicationName.String, usename.String}
+		if waitingSeconds.Valid {
+			longestWait = max(longestWait, waitingSeconds.Float64)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	maxDepth, chains := lockWaitChains(blockers)
+
+	ch <- prometheus.MustNewConstMetric(
+		pgLockWaitChainMaxDepthDesc,
+		prometheus.GaugeValue, float64(maxDepth),
+	)
+	ch <- prometheus.MustNewConstMetric(
+		pgLockWaitChainCountDesc,
+		prometheus.GaugeValue, float64(chains),
+	)
+	ch <- prometheus.MustNewConstMetric(
+		pgLockWaitChainLongestSecondsDesc,
+		prometheus.GaugeValue, longestWait,
+	)
+	ch <- prometheus.MustNewConstMetric(
+		pgLockBlockedBackendsDesc,
+		prometheus.GaugeValue, float64(len(blockers)),
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		pgLockBlockingBackendsDesc,
+		prometheus.GaugeValue, float64(len(blockingClients)),
+	)
+
+	if !c.blockerLabels {
+		return nil
+	}
+	byClient := make(map[lockBlocker]int)
+	for _, client := range blockingClients {
+		byClient[client]++
+	}
+	clients := make([]lockBlocker, 0, len(byClient))
+	for client := range byClient {
+		clients = append(clients, client)
+	}
+	slices.SortFunc(clients, func(a, b lockBlocker) int {
+		return cmp.Or(cmp.Compare(a.applicationName, b.applicationName), cmp.Compare(a.usename, b.usename))
+	})
+	for _, client := range clients {
+		ch <- prometheus.MustNewConstMetric(
+			pgLockBlockingBackendsByClientDesc,
+			prometheus.GaugeValue, float64(byClient[client]),
+			client.applicationName, client.usename,
+		)
+	}
+	return nil
+}
+
+// lockWaitChains walks the wait-for graph given as waiter -> blockers and
+// returns the depth of the longest chain and the number of chain heads.
+// Cycles (a deadlock not yet broken by the server) are cut where they close.
+func lockWaitChains(blockers map[int64][]int64) (maxDepth int, chains int) {
+	depths := make(map[int64]int)
+	visiting := make(map[int64]bool)
+
+	var depth func(pid int64) int
+	depth = func(pid int64) int {
+		if d, ok := depths[pid]; ok {
+			return d
+		}
+		if visiting[pid] {
+			return 0
+		}
+		visiting[pid] = true
+		d := 0
+		for _, b := range blockers[pid] {
+			d = max(d, depth(b))
+		}
+		visiting[pid] = false
+		depths[pid] = d + 1
+		return d + 1
+	}
+
+	heads := make(map[int64]bool)
+	for pid, bs := range blockers {
+		maxDepth = max(maxDepth, depth(pid))
+		for _, b := range bs {
+			if _, waiting := blockers[b]; !waiting {
+				heads[b] = true
+			}
+		}
+	}
+	return maxDepth, len(heads)
+}
@@ -0,0 +1,101 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const lockTableSubsystem = "lock_table"
+
+func init() {
+	registerCollector(lockTableSubsystem, defaultDisabled, NewPGLockTableCollector)
+}
+
+// PGLockTableCollector reports how full the shared lock table is. The table
+// holds max_locks_per_transaction * (max_connections +
+// max_prepared_transactions) entries; once it is exhausted, lock acquisition
+// fails with "out of shared memory".
+type PGLockTableCollector struct {
+	log *slog.Logger
+}
+
+func NewPGLockTableCollector(config collectorConfig) (Collector, error) {
+	return &PGLockTableCollector{log: config.logger}, nil
+}
+
+var (
+	pgLocksTotalDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "locks", "total"),
+		"Number of locks currently held or awaited",
+		[]string{}, nil,
+	)
+	pgLocksLimitDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "locks", "limit"),
+		"Size of the shared lock table: max_locks_per_transaction * (max_connections + max_prepared_transactions)",
+		[]string{}, nil,
+	)
+	pgLocksUsageRatioDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "locks", "usage_ratio"),
+		"Ratio of locks in use to the size of the shared lock table",
+		[]string{}, nil,
+	)
+
+	pgLockTableQuery = `SELECT
+		(SELECT count(*) FROM pg_catalog.pg_locks) AS locks,
+		current_setting('max_locks_per_transaction')::bigint AS max_locks_per_transaction,
+		current_setting('max_connections')::bigint AS max_connections,
+		current_setting('max_prepared_transactions')::bigint AS max_prepared_transactions`
+)
+
+func (c PGLockTableCollector) Update(ctx context.Context, instance *Instance, ch chan<- prometheus.Metric) error {
+	db := instance.getDB()
+
+	var locks, maxLocksPerTransaction, maxConnections, maxPreparedTransactions sql.NullInt64
+	err := scanRowWithOverride(ctx, db, lockTableSubsystem, pgLockTableQuery, nil, &locks, &maxLocksPerTransaction, &maxConnections, &maxPreparedTransactions)
+	if err != nil {
+		return err
+	}
+
+	locksMetric := 0.0
+	if locks.Valid {
+		locksMetric = float64(locks.Int64)
+	}
+	limitMetric := 0.0
+	if maxLocksPerTransaction.Valid && maxConnections.Valid && maxPreparedTransactions.Valid {
+		limitMetric = float64(maxLocksPerTransaction.Int64 * (maxConnections.Int64 + maxPreparedTransactions.Int64))
+	}
+	ratioMetric := 0.0
+	if limitMetric > 0 {
+		ratioMetric = locksMetric / limitMetric
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		pgLocksTotalDesc,
+		prometheus.GaugeValue, locksMetric,
+	)
+	ch <- prometheus.MustNewConstMetric(
+		pgLocksLimitDesc,
+		prometheus.GaugeValue, limitMetric,
+	)
+	ch <- prometheus.MustNewConstMetric(
+		pgLocksUsageRatioDesc,
+		prometheus.GaugeValue, ratioMetric,
+	)
+	return nil
+}
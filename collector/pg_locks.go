@@ -16,8 +16,11 @@ package collector
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"log/slog"
+	"strconv"
 
+	"github.com/blang/semver/v4"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
@@ -27,6 +30,10 @@ func init() {
 	registerCollector(locksSubsystem, defaultEnabled, NewPGLocksCollector)
 }
 
+// PGLocksCollector reports lock counts per database and mode, a breakdown
+// of all of pg_locks by lock type, mode and whether the lock is granted, and
+// how long the longest ungranted lock request has been waiting. All of them
+// come from a single pass over pg_locks.
 type PGLocksCollector struct {
 	log *slog.Logger
 }
@@ -47,83 +54,131 @@ var (
 		"Number of locks",
 		[]string{"datname", "mode"}, nil,
 	)
+	pgLocksDetailDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, locksSubsystem, "detail_count"),
+		"Number of locks by lock type, mode and whether they are granted; datname is empty for locks not tied to a database",
+		[]string{"datname", "locktype", "mode", "granted"}, nil,
+	)
+	pgLocksLongestWaitDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, locksSubsystem, "longest_wait_seconds"),
+		"Seconds the longest ungranted lock request has been waiting, or 0 if none is waiting",
+		[]string{}, nil,
+	)
+
+	// pgLockModes are the modes pg_locks_count is reported for, in every
+	// database, whether or not any lock is held in that mode.
+	pgLockModes = []string{
+		"accesssharelock",
+		"rowsharelock",
+		"rowexclusivelock",
+		"shareupdateexclusivelock",
+		"sharelock",
+		"sharerowexclusivelock",
+		"exclusivelock",
+		"accessexclusivelock",
+		"sireadlock",
+	}
+
+	// pgLocksQueryTemplate groups pg_locks by database, lock type, mode and
+	// granted state, and appends one row per database with a NULL locktype
+	// so that databases without locks are reported too. It takes the
+	// expression for the longest wait of a group: pg_locks.waitstart was
+	// added in PostgreSQL 14.
+	pgLocksQueryTemplate = `SELECT
+		coalesce(d.datname, '') AS datname,
+		l.locktype,
+		lower(l.mode) AS mode,
+		l.granted,
+		count(*) AS count,
+		%s AS longest_wait_seconds
+	FROM pg_catalog.pg_locks l
+	LEFT JOIN pg_catalog.pg_database d ON d.oid = l.database
+	GROUP BY 1, 2, 3, 4
+	UNION ALL
+	SELECT datname, NULL, NULL, NULL, 0, NULL
+	FROM pg_catalog.pg_database
+	ORDER BY 1, 2, 3, 4`
 
-	pgLocksQuery = `
-		SELECT 
-		  pg_database.datname as datname,
-		  tmp.mode as mode,
-		  COALESCE(count, 0) as count 
-		FROM 
-		  (
-		    VALUES 
-		      ('accesssharelock'), 
-		      ('rowsharelock'), 
-		      ('rowexclusivelock'), 
-		      ('shareupdateexclusivelock'), 
-		      ('sharelock'), 
-		      ('sharerowexclusivelock'), 
-		      ('exclusivelock'), 
-		      ('accessexclusivelock'), 
-		      ('sireadlock')
-		  ) AS tmp(mode)
-		  CROSS JOIN pg_database 
-		  LEFT JOIN (
-		    SELECT 
-		      database, 
-		      lower(mode) AS mode, 
-		      count(*) AS count 
-		    FROM 
-		      pg_locks 
-		    WHERE 
-		      database IS NOT NULL 
-		    GROUP BY 
-		      database, 
-		      lower(mode)
-		  ) AS tmp2 ON tmp.mode = tmp2.mode 
-		  and pg_database.oid = tmp2.database 
-		ORDER BY 
-		  1
-	`
+	pgLocksQuery     = fmt.Sprintf(pgLocksQueryTemplate, "NULL::double precision")
+	pgLocksQueryPG14 = fmt.Sprintf(pgLocksQueryTemplate, "max(EXTRACT(EPOCH FROM now() - l.waitstart))")
 )
 
+// lockModeKey identifies a pg_locks_count series.
+type lockModeKey struct {
+	datname string
+	mode    string
+}
+
 // Update implements Collector and exposes database locks.
 // It is called by the Prometheus registry when collecting metrics.
 func (c PGLocksCollector) Update(ctx context.Context, instance *Instance, ch chan<- prometheus.Metric) error {
+	hasWaitStart := instance.version.GTE(semver.MustParse("14.0.0"))
+	query := pgLocksQuery
+	if hasWaitStart {
+		query = pgLocksQueryPG14
+	}
+
 	db := instance.getDB()
-	// Query the list of databases
-	rows, err := db.QueryContext(ctx,
-		pgLocksQuery,
-	)
+	rows, err := queryWithOverride(ctx, db, locksSubsystem, query, 6)
 	if err != nil {
 		return err
 	}
 	defer rows.Close()
 
-	var datname, mode sql.NullString
-	var count sql.NullInt64
-
+	var databases []string
+	counts := make(map[lockModeKey]float64)
+	longestWait := 0.0
 	for rows.Next() {
-		if err := rows.Scan(&datname, &mode, &count); err != nil {
+		var datname, locktype, mode sql.NullString
+		var granted sql.NullBool
+		var count sql.NullInt64
+		var waitSeconds sql.NullFloat64
+		if err := rows.Scan(&datname, &locktype, &mode, &granted, &count, &waitSeconds); err != nil {
 			return err
 		}
 
-		if !datname.Valid || !mode.Valid {
+		if !locktype.Valid {
+			if datname.Valid {
+				databases = append(databases, datname.String)
+			}
 			continue
 		}
-
-		countMetric := 0.0
-		if count.Valid {
-			countMetric = float64(count.Int64)
+		if !mode.Valid || !granted.Valid {
+			continue
 		}
-
 		ch <- prometheus.MustNewConstMetric(
-			pgLocksDesc,
-			prometheus.GaugeValue, countMetric,
-			datname.String, mode.String,
+			pgLocksDetailDesc,
+			prometheus.GaugeValue, float64(count.Int64),
+			datname.String, locktype.String, mode.String, strconv.FormatBool(granted.Bool),
 		)
+
+		if datname.String != "" {
+			counts[lockModeKey{datname.String, mode.String}] += float64(count.Int64)
+		}
+		if !granted.Bool && waitSeconds.Valid {
+			longestWait = max(longestWait, waitSeconds.Float64)
+		}
 	}
 	if err := rows.Err(); err != nil {
 		return err
 	}
+
+	for _, datname := range databases {
+		for _, mode := range pgLockModes {
+			ch <- prometheus.MustNewConstMetric(
+				pgLocksDesc,
+				prometheus.GaugeValue, counts[lockModeKey{datname, mode}],
+				datname, mode,
+			)
+		}
+	}
+
+	if !hasWaitStart {
+		return skipUnsupportedVersion(c.log, "pg_locks.waitstart is not available before PostgreSQL 14")
+	}
+	ch <- prometheus.MustNewConstMetric(
+		pgLocksLongestWaitDesc,
+		prometheus.GaugeValue, longestWait,
+	)
 	return nil
 }
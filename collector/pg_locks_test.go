@@ -14,14 +14,26 @@ package collector
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/blang/semver/v4"
 	"github.com/prometheus/client_golang/prometheus"
 	dto "github.com/prometheus/client_model/go"
 	"github.com/smartystreets/goconvey/convey"
 )
 
+// lockCounts returns the pg_locks_count series of datname, with count for
+// mode and 0 for every other mode.
+func lockCounts(datname string, count map[string]float64) []MetricResult {
+	var metrics []MetricResult
+	for _, mode := range pgLockModes {
+		metrics = append(metrics, MetricResult{labels: labelMap{"datname": datname, "mode": mode}, value: count[mode], metricType: dto.MetricType_GAUGE})
+	}
+	return metrics
+}
+
 func TestPGLocksCollector(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	if err != nil {
@@ -29,11 +41,13 @@ func TestPGLocksCollector(t *testing.T) {
 	}
 	defer db.Close()
 
-	inst := &Instance{db: db}
-
-	rows := sqlmock.NewRows([]string{"datname", "mode", "count"}).
-		AddRow("test", "exclusivelock", 42)
+	inst := &Instance{db: db, version: semver.MustParse("13.0.0")}
 
+	columns := []string{"datname", "locktype", "mode", "granted", "count", "longest_wait_seconds"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("postgres", nil, nil, nil, 0, nil).
+		AddRow("test", "relation", "exclusivelock", true, 42, nil).
+		AddRow("test", nil, nil, nil, 0, nil)
 	mock.ExpectQuery(sanitizeQuery(pgLocksQuery)).WillReturnRows(rows)
 
 	ch := make(chan prometheus.Metric)
@@ -46,15 +60,89 @@ func TestPGLocksCollector(t *testing.T) {
 	}()
 
 	expected := []MetricResult{
-		{labels: labelMap{"datname": "test", "mode": "exclusivelock"}, value: 42, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"datname": "test", "locktype": "relation", "mode": "exclusivelock", "granted": "true"}, value: 42, metricType: dto.MetricType_GAUGE},
+	}
+	expected = append(expected, lockCounts("postgres", nil)...)
+	expected = append(expected, lockCounts("test", map[string]float64{"exclusivelock": 42})...)
+	convey.Convey("Metrics comparison", t, func() {
+		var metrics []MetricResult
+		for m := range ch {
+			metrics = append(metrics, readMetric(m))
+		}
+		convey.So(metrics, convey.ShouldResemble, expected)
+	})
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
+
+func TestPGLocksCollectorLongestWait(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error opening a stub db connection: %s", err)
+	}
+	defer db.Close()
+
+	inst := &Instance{db: db, version: semver.MustParse("14.0.0")}
+
+	columns := []string{"datname", "locktype", "mode", "granted", "count", "longest_wait_seconds"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("", "transactionid", "exclusivelock", true, 3, nil).
+		AddRow("test", "relation", "accessexclusivelock", false, 1, 17.5).
+		AddRow("test", "relation", "accessexclusivelock", true, 1, nil).
+		AddRow("test", nil, nil, nil, 0, nil)
+	mock.ExpectQuery(sanitizeQuery(pgLocksQueryPG14)).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		defer close(ch)
+		c := PGLocksCollector{}
+		if err := c.Update(context.Background(), inst, ch); err != nil {
+			t.Errorf("Error calling PGLocksCollector.Update: %s", err)
+		}
+	}()
+
+	expected := []MetricResult{
+		{labels: labelMap{"datname": "", "locktype": "transactionid", "mode": "exclusivelock", "granted": "true"}, value: 3, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"datname": "test", "locktype": "relation", "mode": "accessexclusivelock", "granted": "false"}, value: 1, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"datname": "test", "locktype": "relation", "mode": "accessexclusivelock", "granted": "true"}, value: 1, metricType: dto.MetricType_GAUGE},
 	}
+	expected = append(expected, lockCounts("test", map[string]float64{"accessexclusivelock": 2})...)
+	expected = append(expected, MetricResult{labels: labelMap{}, value: 17.5, metricType: dto.MetricType_GAUGE})
 	convey.Convey("Metrics comparison", t, func() {
-		for _, expect := range expected {
-			m := readMetric(<-ch)
-			convey.So(expect, convey.ShouldResemble, m)
+		var metrics []MetricResult
+		for m := range ch {
+			metrics = append(metrics, readMetric(m))
 		}
+		convey.So(metrics, convey.ShouldResemble, expected)
 	})
 	if err := mock.ExpectationsWereMet(); err != nil {
 		t.Errorf("there were unfulfilled exceptions: %s", err)
 	}
 }
+
+func TestPGLocksCollectorStrictVersionGating(t *testing.T) {
+	defer func(v bool) { *strictVersionGating = v }(*strictVersionGating)
+	*strictVersionGating = true
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error opening a stub db connection: %s", err)
+	}
+	defer db.Close()
+
+	inst := &Instance{db: db, version: semver.MustParse("13.0.0")}
+
+	columns := []string{"datname", "locktype", "mode", "granted", "count", "longest_wait_seconds"}
+	mock.ExpectQuery(sanitizeQuery(pgLocksQuery)).WillReturnRows(
+		sqlmock.NewRows(columns).AddRow("test", nil, nil, nil, 0, nil))
+
+	ch := make(chan prometheus.Metric, len(pgLockModes))
+	c := PGLocksCollector{}
+	if err := c.Update(context.Background(), inst, ch); !errors.Is(err, ErrUnsupportedVersion) {
+		t.Errorf("expected ErrUnsupportedVersion for the longest wait before PostgreSQL 14, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
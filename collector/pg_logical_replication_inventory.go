@@ -0,0 +1,142 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+
+	"github.com/blang/semver/v4"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const logicalReplicationInventorySubsystem = "logical_replication_inventory"
+
+func init() {
+	registerCollector(logicalReplicationInventorySubsystem, defaultDisabled, NewPGLogicalReplicationInventoryCollector)
+}
+
+// PGLogicalReplicationInventoryCollector reports the publications and
+// subscriptions defined in the current database, so that a publisher and
+// its subscribers drifting apart, such as a table missing from a
+// publication or a disabled subscription, can be alerted on.
+type PGLogicalReplicationInventoryCollector struct {
+	log *slog.Logger
+}
+
+func NewPGLogicalReplicationInventoryCollector(config collectorConfig) (Collector, error) {
+	return &PGLogicalReplicationInventoryCollector{log: config.logger}, nil
+}
+
+var (
+	pgPublicationTablesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "publication", "tables"),
+		"Number of tables published by the publication",
+		[]string{"datname", "pubname"}, nil,
+	)
+	pgSubscriptionEnabledDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "subscription", "enabled"),
+		"Whether the subscription is enabled",
+		[]string{"datname", "subname", "slot_name"}, nil,
+	)
+
+	pgPublicationsQuery = `SELECT
+		current_database() AS datname,
+		p.pubname,
+		count(pt.tablename) AS tables
+	FROM pg_catalog.pg_publication p
+	LEFT JOIN pg_catalog.pg_publication_tables pt ON pt.pubname = p.pubname
+	GROUP BY p.pubname
+	ORDER BY p.pubname`
+
+	pgSubscriptionsQuery = `SELECT
+		d.datname,
+		s.subname,
+		s.subslotname,
+		s.subenabled
+	FROM pg_catalog.pg_subscription s
+	JOIN pg_catalog.pg_database d ON d.oid = s.subdbid
+	WHERE d.datname = current_database()
+	ORDER BY s.subname`
+)
+
+func (c PGLogicalReplicationInventoryCollector) Update(ctx context.Context, instance *Instance, ch chan<- prometheus.Metric) error {
+	if instance.version.LT(semver.MustParse("10.0.0")) {
+		return skipUnsupportedVersion(c.log, "logical replication is not available before PostgreSQL 10")
+	}
+
+	db := instance.getDB()
+	if err := c.updatePublications(ctx, db, ch); err != nil {
+		return err
+	}
+	return c.updateSubscriptions(ctx, db, ch)
+}
+
+func (c PGLogicalReplicationInventoryCollector) updatePublications(ctx context.Context, db *sql.DB, ch chan<- prometheus.Metric) error {
+	rows, err := queryWithOverride(ctx, db, logicalReplicationInventorySubsystem, pgPublicationsQuery, 3)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var datname, pubname sql.NullString
+		var tables sql.NullInt64
+		if err := rows.Scan(&datname, &pubname, &tables); err != nil {
+			return err
+		}
+		if !datname.Valid || !pubname.Valid {
+			continue
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			pgPublicationTablesDesc,
+			prometheus.GaugeValue, float64(tables.Int64),
+			datname.String, pubname.String,
+		)
+	}
+	return rows.Err()
+}
+
+func (c PGLogicalReplicationInventoryCollector) updateSubscriptions(ctx context.Context, db *sql.DB, ch chan<- prometheus.Metric) error {
+	rows, err := db.QueryContext(ctx, pgSubscriptionsQuery)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var datname, subname, slotName sql.NullString
+		var enabled sql.NullBool
+		if err := rows.Scan(&datname, &subname, &slotName, &enabled); err != nil {
+			return err
+		}
+		if !datname.Valid || !subname.Valid {
+			continue
+		}
+
+		enabledMetric := 0.0
+		if enabled.Bool {
+			enabledMetric = 1
+		}
+		// slot_name is NULL for subscriptions created with slot_name = NONE.
+		ch <- prometheus.MustNewConstMetric(
+			pgSubscriptionEnabledDesc,
+			prometheus.GaugeValue, enabledMetric,
+			datname.String, subname.String, slotName.String,
+		)
+	}
+	return rows.Err()
+}
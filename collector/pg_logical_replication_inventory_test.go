@@ -0,0 +1,72 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package collector
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/blang/semver/v4"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+)
+
+func TestPGLogicalReplicationInventoryCollector(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error opening a stub db connection: %s", err)
+	}
+	defer db.Close()
+
+	inst := &Instance{db: db, version: semver.MustParse("16.0.0")}
+
+	publications := sqlmock.NewRows([]string{"datname", "pubname", "tables"}).
+		AddRow("app", "orders_pub", 12).
+		AddRow("app", "empty_pub", 0)
+	mock.ExpectQuery(sanitizeQuery(pgPublicationsQuery)).WillReturnRows(publications)
+
+	subscriptions := sqlmock.NewRows([]string{"datname", "subname", "subslotname", "subenabled"}).
+		AddRow("app", "billing_sub", "billing_slot", true).
+		AddRow("app", "paused_sub", nil, false)
+	mock.ExpectQuery(sanitizeQuery(pgSubscriptionsQuery)).WillReturnRows(subscriptions)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		defer close(ch)
+		c := PGLogicalReplicationInventoryCollector{}
+
+		if err := c.Update(context.Background(), inst, ch); err != nil {
+			t.Errorf("Error calling PGLogicalReplicationInventoryCollector.Update: %s", err)
+		}
+	}()
+
+	expected := []MetricResult{
+		{labels: labelMap{"datname": "app", "pubname": "orders_pub"}, value: 12, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"datname": "app", "pubname": "empty_pub"}, value: 0, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"datname": "app", "subname": "billing_sub", "slot_name": "billing_slot"}, value: 1, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"datname": "app", "subname": "paused_sub", "slot_name": ""}, value: 0, metricType: dto.MetricType_GAUGE},
+	}
+
+	convey.Convey("Metrics comparison", t, func() {
+		var metrics []MetricResult
+		for m := range ch {
+			metrics = append(metrics, readMetric(m))
+		}
+		convey.So(metrics, convey.ShouldResemble, expected)
+	})
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
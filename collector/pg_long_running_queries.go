@@ -0,0 +1,166 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"sort"
+	"time"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const longRunningQueriesSubsystem = "long_running_queries"
+
+var longRunningQueriesThresholdsFlag *[]string = nil
+
+func init() {
+	registerCollector(longRunningQueriesSubsystem, defaultDisabled, NewPGLongRunningQueriesCollector)
+
+	longRunningQueriesThresholdsFlag = kingpin.Flag(
+		fmt.Sprint(collectorFlagPrefix, longRunningQueriesSubsystem, ".threshold"),
+		"Query duration threshold to count active queries against. May be repeated.").
+		Default("1m", "5m", "15m").
+		Strings()
+}
+
+type longRunningQueriesThreshold struct {
+	label    string
+	duration time.Duration
+}
+
+// PGLongRunningQueriesCollector counts active statements that have been
+// running for longer than each configured threshold. Unlike
+// long_running_transactions it looks at query_start, so an idle-in-transaction
+// session or a transaction issuing many short statements is not counted.
+type PGLongRunningQueriesCollector struct {
+	log                     *slog.Logger
+	thresholds              []longRunningQueriesThreshold
+	excludeApplicationNames []string
+	skipOnStandby           bool
+	roleLabel               bool
+}
+
+func NewPGLongRunningQueriesCollector(config collectorConfig) (Collector, error) {
+	thresholds, err := parseLongRunningQueriesThresholds(*longRunningQueriesThresholdsFlag)
+	if err != nil {
+		return nil, err
+	}
+	return &PGLongRunningQueriesCollector{
+		log:                     config.logger,
+		thresholds:              thresholds,
+		excludeApplicationNames: excludeApplicationNames(),
+		skipOnStandby:           activitySkipOnStandby(),
+		roleLabel:               activityRoleLabel(),
+	}, nil
+}
+
+// parseLongRunningQueriesThresholds parses the threshold flag values, keeping
+// the original text as the metric label, sorted from shortest to longest.
+func parseLongRunningQueriesThresholds(values []string) ([]longRunningQueriesThreshold, error) {
+	thresholds := make([]longRunningQueriesThreshold, 0, len(values))
+	for _, v := range values {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid long_running_queries threshold %q: %w", v, err)
+		}
+		if d <= 0 {
+			return nil, fmt.Errorf("invalid long_running_queries threshold %q: must be positive", v)
+		}
+		thresholds = append(thresholds, longRunningQueriesThreshold{label: v, duration: d})
+	}
+	sort.Slice(thresholds, func(i, j int) bool {
+		return thresholds[i].duration < thresholds[j].duration
+	})
+	return thresholds, nil
+}
+
+var (
+	pgLongRunningQueriesCountDesc = newActivityDesc(
+		prometheus.BuildFQName(namespace, longRunningQueriesSubsystem, "count"),
+		"Number of active queries running for at least the threshold duration",
+		[]string{"threshold"},
+	)
+
+	pgLongRunningQueriesQueryBase = `SELECT
+		EXTRACT(EPOCH FROM clock_timestamp() - query_start) AS query_seconds
+	FROM pg_catalog.pg_stat_activity`
+)
+
+func (c PGLongRunningQueriesCollector) query() string {
+	return newQueryBuilder(pgLongRunningQueriesQueryBase).
+		where("state = 'active'").
+		where("query_start IS NOT NULL").
+		where(excludeSelfCondition(c.excludeApplicationNames)).
+		where("query NOT LIKE 'autovacuum:%'").
+		where("clock_timestamp() - query_start >= $1 * interval '1 second'").
+		String()
+}
+
+func (c PGLongRunningQueriesCollector) Update(ctx context.Context, instance *Instance, ch chan<- prometheus.Metric) error {
+	if len(c.thresholds) == 0 {
+		return nil
+	}
+
+	role, skip, err := activityRole(ctx, instance, c.skipOnStandby, c.roleLabel, c.log)
+	if err != nil || skip {
+		return err
+	}
+	db := instance.getDB()
+	rows, err := queryWithOverride(ctx, db, longRunningQueriesSubsystem, c.query(), 1, c.thresholds[0].duration.Seconds())
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var durations []float64
+	for rows.Next() {
+		var seconds sql.NullFloat64
+		if err := rows.Scan(&seconds); err != nil {
+			return err
+		}
+		if seconds.Valid {
+			durations = append(durations, seconds.Float64)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for i, count := range bucketLongRunningQueries(c.thresholds, durations) {
+		ch <- pgLongRunningQueriesCountDesc.mustNewConstMetric(
+			role, prometheus.GaugeValue, float64(count),
+			c.thresholds[i].label,
+		)
+	}
+	return nil
+}
+
+// bucketLongRunningQueries returns, for each threshold, how many of the query
+// durations (in seconds) are at least that long.
+func bucketLongRunningQueries(thresholds []longRunningQueriesThreshold, durations []float64) []int {
+	counts := make([]int, len(thresholds))
+	for _, d := range durations {
+		for i, t := range thresholds {
+			if d >= t.duration.Seconds() {
+				counts[i]++
+			}
+		}
+	}
+	return counts
+}
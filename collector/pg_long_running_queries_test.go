@@ -0,0 +1,79 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package collector
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+)
+
+func TestPGLongRunningQueriesCollector(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error opening a stub db connection: %s", err)
+	}
+	defer db.Close()
+
+	inst := &Instance{db: db}
+
+	thresholds, err := parseLongRunningQueriesThresholds([]string{"5m", "1m", "15m"})
+	if err != nil {
+		t.Fatalf("Error parsing thresholds: %s", err)
+	}
+
+	rows := sqlmock.NewRows([]string{"query_seconds"}).
+		AddRow(60).
+		AddRow(120).
+		AddRow(400).
+		AddRow(1000)
+	mock.ExpectQuery(sanitizeQuery(PGLongRunningQueriesCollector{}.query())).WithArgs(float64(60)).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		defer close(ch)
+		c := PGLongRunningQueriesCollector{thresholds: thresholds}
+
+		if err := c.Update(context.Background(), inst, ch); err != nil {
+			t.Errorf("Error calling PGLongRunningQueriesCollector.Update: %s", err)
+		}
+	}()
+
+	expected := []MetricResult{
+		{labels: labelMap{"threshold": "1m"}, value: 4, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"threshold": "5m"}, value: 2, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"threshold": "15m"}, value: 1, metricType: dto.MetricType_GAUGE},
+	}
+
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range expected {
+			m := readMetric(<-ch)
+			convey.So(expect, convey.ShouldResemble, m)
+		}
+	})
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
+
+func TestParseLongRunningQueriesThresholdsInvalid(t *testing.T) {
+	for _, v := range []string{"soon", "0s", "-1m"} {
+		if _, err := parseLongRunningQueriesThresholds([]string{v}); err == nil {
+			t.Errorf("expected an error for threshold %q", v)
+		}
+	}
+}
@@ -15,55 +15,113 @@ package collector
 
 import (
 	"context"
+	"database/sql"
+	"fmt"
 	"log/slog"
 
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/lib/pq"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
 const longRunningTransactionsSubsystem = "long_running_transactions"
 
+var (
+	longRunningTransactionsIncludeAutovacuumFlag *bool   = nil
+	longRunningTransactionsDatabaseFlag          *string = nil
+)
+
 func init() {
 	registerCollector(longRunningTransactionsSubsystem, defaultDisabled, NewPGLongRunningTransactionsCollector)
+
+	longRunningTransactionsIncludeAutovacuumFlag = kingpin.Flag(
+		fmt.Sprint(collectorFlagPrefix, longRunningTransactionsSubsystem, ".include-autovacuum"),
+		"Count autovacuum workers as long running transactions. (default: disabled)").
+		Default(fmt.Sprintf("%v", defaultDisabled)).
+		Bool()
+	longRunningTransactionsDatabaseFlag = kingpin.Flag(
+		fmt.Sprint(collectorFlagPrefix, longRunningTransactionsSubsystem, ".database"),
+		"Only count transactions in this database. (default: all databases)").
+		Default("").
+		String()
 }
 
 type PGLongRunningTransactionsCollector struct {
-	log *slog.Logger
+	log                     *slog.Logger
+	includeAutovacuum       bool
+	database                string
+	excludeApplicationNames []string
+	skipOnStandby           bool
+	roleLabel               bool
 }
 
 func NewPGLongRunningTransactionsCollector(config collectorConfig) (Collector, error) {
-	return &PGLongRunningTransactionsCollector{log: config.logger}, nil
+	return &PGLongRunningTransactionsCollector{
+		log:                     config.logger,
+		includeAutovacuum:       *longRunningTransactionsIncludeAutovacuumFlag,
+		database:                *longRunningTransactionsDatabaseFlag,
+		excludeApplicationNames: excludeApplicationNames(),
+		skipOnStandby:           activitySkipOnStandby(),
+		roleLabel:               activityRoleLabel(),
+	}, nil
 }
 
 var (
-	longRunningTransactionsCount = prometheus.NewDesc(
+	longRunningTransactionsCount = newActivityDesc(
 		"pg_long_running_transactions",
 		"Current number of long running transactions",
 		[]string{},
-		prometheus.Labels{},
 	)
 
-	longRunningTransactionsAgeInSeconds = prometheus.NewDesc(
+	longRunningTransactionsAgeInSeconds = newActivityDesc(
 		prometheus.BuildFQName(namespace, longRunningTransactionsSubsystem, "oldest_timestamp_seconds"),
 		"The current maximum transaction age in seconds",
 		[]string{},
-		prometheus.Labels{},
 	)
 
-	longRunningTransactionsQuery = `
-	SELECT                                                              
+	longRunningTransactionsQueryBase = `
+	SELECT
     COUNT(*) as transactions,
-    MAX(EXTRACT(EPOCH FROM clock_timestamp() - pg_stat_activity.xact_start)) AS oldest_timestamp_seconds
-FROM pg_catalog.pg_stat_activity
-WHERE state IS DISTINCT FROM 'idle'
-AND query NOT LIKE 'autovacuum:%'
-AND pg_stat_activity.xact_start IS NOT NULL;
-	`
+    COALESCE(MAX(EXTRACT(EPOCH FROM clock_timestamp() - pg_stat_activity.xact_start)), 0) AS oldest_timestamp_seconds
+FROM pg_catalog.pg_stat_activity`
+
+	longRunningTransactionsAutovacuumFilter = `query NOT LIKE 'autovacuum:%'`
 )
 
-func (PGLongRunningTransactionsCollector) Update(ctx context.Context, instance *Instance, ch chan<- prometheus.Metric) error {
+// query returns the long running transactions query, excluding the exporter's
+// own backend, autovacuum workers unless includeAutovacuum is set, and limited
+// to a single database when one is configured.
+func (c PGLongRunningTransactionsCollector) query() string {
+	q := newQueryBuilder(longRunningTransactionsQueryBase).
+		where("state IS DISTINCT FROM 'idle'").
+		where("pg_stat_activity.xact_start IS NOT NULL").
+		where(excludeSelfCondition(c.excludeApplicationNames))
+	if !c.includeAutovacuum {
+		q.where(longRunningTransactionsAutovacuumFilter)
+	}
+	if c.database != "" {
+		q.where("datname = " + pq.QuoteLiteral(c.database))
+	}
+	return q.String()
+}
+
+func (c PGLongRunningTransactionsCollector) Describe(ch chan<- *prometheus.Desc) {
+	if c.roleLabel {
+		ch <- longRunningTransactionsCount.withRole
+		ch <- longRunningTransactionsAgeInSeconds.withRole
+		return
+	}
+	ch <- longRunningTransactionsCount.plain
+	ch <- longRunningTransactionsAgeInSeconds.plain
+}
+
+func (c PGLongRunningTransactionsCollector) Update(ctx context.Context, instance *Instance, ch chan<- prometheus.Metric) error {
+	role, skip, err := activityRole(ctx, instance, c.skipOnStandby, c.roleLabel, c.log)
+	if err != nil || skip {
+		return err
+	}
 	db := instance.getDB()
-	rows, err := db.QueryContext(ctx,
-		longRunningTransactionsQuery)
+	rows, err := queryWithOverride(ctx, db, longRunningTransactionsSubsystem, c.query(), 2)
 
 	if err != nil {
 		return err
@@ -71,21 +129,21 @@ func (PGLongRunningTransactionsCollector) Update(ctx context.Context, instance *
 	defer rows.Close()
 
 	for rows.Next() {
-		var transactions, ageInSeconds float64
+		var transactions, ageInSeconds sql.NullFloat64
 
 		if err := rows.Scan(&transactions, &ageInSeconds); err != nil {
 			return err
 		}
 
-		ch <- prometheus.MustNewConstMetric(
-			longRunningTransactionsCount,
+		ch <- longRunningTransactionsCount.mustNewConstMetric(
+			role,
 			prometheus.GaugeValue,
-			transactions,
+			transactions.Float64,
 		)
-		ch <- prometheus.MustNewConstMetric(
-			longRunningTransactionsAgeInSeconds,
+		ch <- longRunningTransactionsAgeInSeconds.mustNewConstMetric(
+			role,
 			prometheus.GaugeValue,
-			ageInSeconds,
+			ageInSeconds.Float64,
 		)
 	}
 	if err := rows.Err(); err != nil {
@@ -14,6 +14,7 @@ package collector
 
 import (
 	"context"
+	"strings"
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
@@ -36,7 +37,7 @@ func TestPGLongRunningTransactionsCollector(t *testing.T) {
 	rows := sqlmock.NewRows(columns).
 		AddRow(20, 1200)
 
-	mock.ExpectQuery(sanitizeQuery(longRunningTransactionsQuery)).WillReturnRows(rows)
+	mock.ExpectQuery(sanitizeQuery(PGLongRunningTransactionsCollector{}.query())).WillReturnRows(rows)
 
 	ch := make(chan prometheus.Metric)
 	go func() {
@@ -61,3 +62,123 @@ func TestPGLongRunningTransactionsCollector(t *testing.T) {
 		t.Errorf("there were unfulfilled exceptions: %s", err)
 	}
 }
+
+func TestPGLongRunningTransactionsCollectorIncludeAutovacuum(t *testing.T) {
+	tests := []struct {
+		name              string
+		includeAutovacuum bool
+		transactions      int
+	}{
+		// The mocked server has two client transactions and one autovacuum worker;
+		// the query only matches when the autovacuum filter is applied as expected.
+		{name: "autovacuum excluded", includeAutovacuum: false, transactions: 2},
+		{name: "autovacuum included", includeAutovacuum: true, transactions: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("Error opening a stub db connection: %s", err)
+			}
+			defer db.Close()
+			inst := &Instance{db: db}
+
+			c := PGLongRunningTransactionsCollector{includeAutovacuum: tt.includeAutovacuum}
+			query := c.query()
+			if strings.Contains(query, longRunningTransactionsAutovacuumFilter) == tt.includeAutovacuum {
+				t.Fatalf("unexpected autovacuum filter in query: %s", query)
+			}
+
+			rows := sqlmock.NewRows([]string{"transactions", "age_in_seconds"}).
+				AddRow(tt.transactions, 600)
+			mock.ExpectQuery(sanitizeQuery(query)).WillReturnRows(rows)
+
+			ch := make(chan prometheus.Metric)
+			go func() {
+				defer close(ch)
+				if err := c.Update(context.Background(), inst, ch); err != nil {
+					t.Errorf("Error calling PGLongRunningTransactionsCollector.Update: %s", err)
+				}
+			}()
+
+			expected := []MetricResult{
+				{labels: labelMap{}, value: float64(tt.transactions), metricType: dto.MetricType_GAUGE},
+				{labels: labelMap{}, value: 600, metricType: dto.MetricType_GAUGE},
+			}
+			convey.Convey("Metrics comparison", t, func() {
+				for _, expect := range expected {
+					m := readMetric(<-ch)
+					convey.So(expect, convey.ShouldResemble, m)
+				}
+			})
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled exceptions: %s", err)
+			}
+		})
+	}
+}
+
+func TestPGLongRunningTransactionsCollectorDatabase(t *testing.T) {
+	tests := []struct {
+		name     string
+		database string
+		want     string
+	}{
+		{name: "cluster wide", database: "", want: ""},
+		{name: "single database", database: "tenant_a", want: "datname = 'tenant_a'"},
+		{name: "quoted database", database: "it's", want: "datname = 'it''s'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query := PGLongRunningTransactionsCollector{database: tt.database}.query()
+			if tt.want == "" {
+				if strings.Contains(query, "datname") {
+					t.Errorf("expected no datname filter, got: %s", query)
+				}
+				return
+			}
+			if !strings.Contains(query, tt.want) {
+				t.Errorf("expected query to contain %q, got: %s", tt.want, query)
+			}
+		})
+	}
+}
+
+func TestPGLongRunningTransactionsCollectorNoTransactions(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error opening a stub db connection: %s", err)
+	}
+	defer db.Close()
+	inst := &Instance{db: db}
+
+	// A query override without the COALESCE returns a NULL age when no
+	// transactions match; that must not fail the scan.
+	rows := sqlmock.NewRows([]string{"transactions", "age_in_seconds"}).
+		AddRow(0, nil)
+	c := PGLongRunningTransactionsCollector{excludeApplicationNames: []string{"pgbouncer"}}
+	mock.ExpectQuery(sanitizeQuery(c.query())).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		defer close(ch)
+		if err := c.Update(context.Background(), inst, ch); err != nil {
+			t.Errorf("Error calling PGLongRunningTransactionsCollector.Update: %s", err)
+		}
+	}()
+	expected := []MetricResult{
+		{labels: labelMap{}, value: 0, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{}, value: 0, metricType: dto.MetricType_GAUGE},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range expected {
+			m := readMetric(<-ch)
+			convey.So(expect, convey.ShouldResemble, m)
+		}
+	})
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
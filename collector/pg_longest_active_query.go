@@ -0,0 +1,129 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const longestActiveQuerySubsystem = "longest_active_query"
+
+func init() {
+	registerCollector(longestActiveQuerySubsystem, defaultDisabled, NewPGLongestActiveQueryCollector)
+}
+
+// PGLongestActiveQueryCollector reports the runtime of the longest query
+// currently executing, ignoring autovacuum and the exporter's own backend,
+// along with an info series identifying the backend running it.
+type PGLongestActiveQueryCollector struct {
+	log                     *slog.Logger
+	excludeApplicationNames []string
+	skipOnStandby           bool
+	roleLabel               bool
+}
+
+func NewPGLongestActiveQueryCollector(config collectorConfig) (Collector, error) {
+	return &PGLongestActiveQueryCollector{
+		log:                     config.logger,
+		excludeApplicationNames: excludeApplicationNames(),
+		skipOnStandby:           activitySkipOnStandby(),
+		roleLabel:               activityRoleLabel(),
+	}, nil
+}
+
+var (
+	pgLongestActiveQuerySecondsDesc = newActivityDesc(
+		prometheus.BuildFQName(namespace, longestActiveQuerySubsystem, "seconds"),
+		"Runtime in seconds of the longest currently executing query (0 when none are running)",
+		[]string{},
+	)
+	pgLongestActiveQueryInfoDesc = newActivityDesc(
+		prometheus.BuildFQName(namespace, longestActiveQuerySubsystem, "info"),
+		"Backend running the longest currently executing query (value is always 1)",
+		[]string{"pid", "usename", "datname"},
+	)
+
+	pgLongestActiveQueryQueryBase = `SELECT
+		pid,
+		usename,
+		datname,
+		EXTRACT(EPOCH FROM (clock_timestamp() - query_start)) AS seconds
+	FROM pg_catalog.pg_stat_activity`
+)
+
+// query returns the single longest running active query, if any.
+func (c PGLongestActiveQueryCollector) query() string {
+	q := newQueryBuilder(pgLongestActiveQueryQueryBase).
+		where("state = 'active'").
+		where("query_start IS NOT NULL").
+		where("query NOT LIKE 'autovacuum:%'").
+		where(excludeSelfCondition(c.excludeApplicationNames))
+	return terminateQuery(q.subquery() + "\nORDER BY seconds DESC\nLIMIT 1")
+}
+
+func (c PGLongestActiveQueryCollector) Update(ctx context.Context, instance *Instance, ch chan<- prometheus.Metric) error {
+	role, skip, err := activityRole(ctx, instance, c.skipOnStandby, c.roleLabel, c.log)
+	if err != nil || skip {
+		return err
+	}
+	db := instance.getDB()
+	rows, err := queryWithOverride(ctx, db, longestActiveQuerySubsystem, c.query(), 4)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var (
+		found          bool
+		longestSeconds float64
+		longestPID     int64
+		longestUsename string
+		longestDatname string
+	)
+	for rows.Next() {
+		var pid sql.NullInt64
+		var usename, datname sql.NullString
+		var seconds sql.NullFloat64
+		if err := rows.Scan(&pid, &usename, &datname, &seconds); err != nil {
+			return err
+		}
+		if !pid.Valid || !seconds.Valid {
+			continue
+		}
+		found = true
+		longestSeconds = seconds.Float64
+		longestPID = pid.Int64
+		longestUsename = usename.String
+		longestDatname = datname.String
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	ch <- pgLongestActiveQuerySecondsDesc.mustNewConstMetric(
+		role, prometheus.GaugeValue, longestSeconds,
+	)
+	if found {
+		ch <- pgLongestActiveQueryInfoDesc.mustNewConstMetric(
+			role, prometheus.GaugeValue, 1,
+			strconv.FormatInt(longestPID, 10), longestUsename, longestDatname,
+		)
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/blang/semver/v4"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const partitionedTablesSubsystem = "partitioned_tables"
+
+var partitionedTablesTopNFlag *uint = nil
+
+func init() {
+	registerCollector(partitionedTablesSubsystem, defaultDisabled, NewPGPartitionedTablesCollector)
+
+	partitionedTablesTopNFlag = kingpin.Flag(
+		fmt.Sprint(collectorFlagPrefix, partitionedTablesSubsystem, ".top-n"),
+		"Number of partitioned tables with the most partitions to report.").
+		Default("20").
+		Uint()
+}
+
+// PGPartitionedTablesCollector reports the number of direct partitions of the
+// partitioned tables with the most partitions. Planning time grows with the
+// partition count, so over-partitioned tables are worth spotting early.
+type PGPartitionedTablesCollector struct {
+	log  *slog.Logger
+	topN uint
+}
+
+func NewPGPartitionedTablesCollector(config collectorConfig) (Collector, error) {
+	return &PGPartitionedTablesCollector{
+		log:  config.logger,
+		topN: *partitionedTablesTopNFlag,
+	}, nil
+}
+
+var (
+	pgPartitionedTablePartitionsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "partitioned_table", "partitions"),
+		"Number of partitions attached directly to the partitioned table",
+		[]string{"datname", "schemaname", "relname"}, nil,
+	)
+
+	pgPartitionedTablesQuery = `SELECT
+		current_database() AS datname,
+		n.nspname AS schemaname,
+		c.relname,
+		count(i.inhrelid) AS partitions
+	FROM pg_catalog.pg_partitioned_table p
+	JOIN pg_catalog.pg_class c ON c.oid = p.partrelid
+	JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+	LEFT JOIN pg_catalog.pg_inherits i ON i.inhparent = p.partrelid
+	GROUP BY n.nspname, c.relname
+	ORDER BY partitions DESC
+	LIMIT $1`
+)
+
+func (c PGPartitionedTablesCollector) Update(ctx context.Context, instance *Instance, ch chan<- prometheus.Metric) error {
+	if instance.version.LT(semver.MustParse("10.0.0")) {
+		return skipUnsupportedVersion(c.log, "declarative partitioning is not available before PostgreSQL 10")
+	}
+
+	db := instance.getDB()
+	rows, err := queryWithOverride(ctx, db, partitionedTablesSubsystem, pgPartitionedTablesQuery, 4, c.topN)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var datname, schemaname, relname sql.NullString
+		var partitions sql.NullInt64
+		if err := rows.Scan(&datname, &schemaname, &relname, &partitions); err != nil {
+			return err
+		}
+
+		if !datname.Valid || !schemaname.Valid || !relname.Valid {
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(
+			pgPartitionedTablePartitionsDesc,
+			prometheus.GaugeValue, float64(partitions.Int64),
+			datname.String, schemaname.String, relname.String,
+		)
+	}
+	return rows.Err()
+}
@@ -23,7 +23,7 @@ import (
 const postmasterSubsystem = "postmaster"
 
 func init() {
-	registerCollector(postmasterSubsystem, defaultDisabled, NewPGPostmasterCollector)
+	registerCollector(postmasterSubsystem, defaultEnabled, NewPGPostmasterCollector)
 }
 
 type PGPostmasterCollector struct {
@@ -43,17 +43,26 @@ var (
 		"Time at which postmaster started",
 		[]string{}, nil,
 	)
+	pgUptimeSeconds = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "uptime_seconds"),
+		"Seconds since postmaster started",
+		[]string{}, nil,
+	)
 
-	pgPostmasterQuery = "SELECT extract(epoch from pg_postmaster_start_time) from pg_postmaster_start_time();"
+	// The uptime is computed on the server so that clock skew between the
+	// exporter and the database does not distort it.
+	pgPostmasterQuery = "SELECT extract(epoch from pg_postmaster_start_time), extract(epoch from now() - pg_postmaster_start_time) from pg_postmaster_start_time();"
 )
 
+func (c *PGPostmasterCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- pgPostMasterStartTimeSeconds
+	ch <- pgUptimeSeconds
+}
+
 func (c *PGPostmasterCollector) Update(ctx context.Context, instance *Instance, ch chan<- prometheus.Metric) error {
 	db := instance.getDB()
-	row := db.QueryRowContext(ctx,
-		pgPostmasterQuery)
-
-	var startTimeSeconds sql.NullFloat64
-	err := row.Scan(&startTimeSeconds)
+	var startTimeSeconds, uptimeSeconds sql.NullFloat64
+	err := scanRowWithOverride(ctx, db, postmasterSubsystem, pgPostmasterQuery, nil, &startTimeSeconds, &uptimeSeconds)
 	if err != nil {
 		return err
 	}
@@ -65,5 +74,11 @@ func (c *PGPostmasterCollector) Update(ctx context.Context, instance *Instance,
 		pgPostMasterStartTimeSeconds,
 		prometheus.GaugeValue, startTimeSecondsMetric,
 	)
+	if uptimeSeconds.Valid {
+		ch <- prometheus.MustNewConstMetric(
+			pgUptimeSeconds,
+			prometheus.GaugeValue, uptimeSeconds.Float64,
+		)
+	}
 	return nil
 }
@@ -31,8 +31,8 @@ func TestPgPostmasterCollector(t *testing.T) {
 
 	inst := &Instance{db: db}
 
-	mock.ExpectQuery(sanitizeQuery(pgPostmasterQuery)).WillReturnRows(sqlmock.NewRows([]string{"pg_postmaster_start_time"}).
-		AddRow(1685739904))
+	mock.ExpectQuery(sanitizeQuery(pgPostmasterQuery)).WillReturnRows(sqlmock.NewRows([]string{"pg_postmaster_start_time", "uptime_seconds"}).
+		AddRow(1685739904, 3600.5))
 
 	ch := make(chan prometheus.Metric)
 	go func() {
@@ -46,6 +46,7 @@ func TestPgPostmasterCollector(t *testing.T) {
 
 	expected := []MetricResult{
 		{labels: labelMap{}, value: 1685739904, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{}, value: 3600.5, metricType: dto.MetricType_GAUGE},
 	}
 	convey.Convey("Metrics comparison", t, func() {
 		for _, expect := range expected {
@@ -67,8 +68,8 @@ func TestPgPostmasterCollectorNullTime(t *testing.T) {
 
 	inst := &Instance{db: db}
 
-	mock.ExpectQuery(sanitizeQuery(pgPostmasterQuery)).WillReturnRows(sqlmock.NewRows([]string{"pg_postmaster_start_time"}).
-		AddRow(nil))
+	mock.ExpectQuery(sanitizeQuery(pgPostmasterQuery)).WillReturnRows(sqlmock.NewRows([]string{"pg_postmaster_start_time", "uptime_seconds"}).
+		AddRow(nil, nil))
 
 	ch := make(chan prometheus.Metric)
 	go func() {
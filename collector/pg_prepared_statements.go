@@ -0,0 +1,78 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const preparedStatementsSubsystem = "prepared_statements"
+
+func init() {
+	// WARNING:
+	//   Disabled by default because pg_prepared_statements only shows the
+	//   statements of the current session, i.e. the exporter's own connection.
+	registerCollector(preparedStatementsSubsystem, defaultDisabled, NewPGPreparedStatementsCollector)
+}
+
+// PGPreparedStatementsCollector counts the server-side prepared statements
+// visible in pg_prepared_statements.
+//
+// PostgreSQL does not expose prepared statements across sessions, so this
+// only reflects the exporter's own session. It is mostly useful when the
+// exporter shares a pooled connection with the application.
+type PGPreparedStatementsCollector struct {
+	log *slog.Logger
+}
+
+func NewPGPreparedStatementsCollector(config collectorConfig) (Collector, error) {
+	return &PGPreparedStatementsCollector{log: config.logger}, nil
+}
+
+var (
+	pgPreparedStatementsCountDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, preparedStatementsSubsystem, "count"),
+		"Number of prepared statements in the current session",
+		[]string{}, nil,
+	)
+
+	pgPreparedStatementsQuery = "SELECT count(*) FROM pg_catalog.pg_prepared_statements"
+)
+
+func (c PGPreparedStatementsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- pgPreparedStatementsCountDesc
+}
+
+func (c PGPreparedStatementsCollector) Update(ctx context.Context, instance *Instance, ch chan<- prometheus.Metric) error {
+	db := instance.getDB()
+
+	var count sql.NullInt64
+	if err := scanRowWithOverride(ctx, db, preparedStatementsSubsystem, pgPreparedStatementsQuery, nil, &count); err != nil {
+		return err
+	}
+
+	countMetric := 0.0
+	if count.Valid {
+		countMetric = float64(count.Int64)
+	}
+	ch <- prometheus.MustNewConstMetric(
+		pgPreparedStatementsCountDesc,
+		prometheus.GaugeValue, countMetric,
+	)
+	return nil
+}
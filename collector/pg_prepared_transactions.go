@@ -0,0 +1,123 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const preparedTransactionsSubsystem = "prepared_transactions"
+
+var preparedTransactionsMinAgeFlag *time.Duration = nil
+
+func init() {
+	registerCollector(preparedTransactionsSubsystem, defaultDisabled, NewPGPreparedTransactionsCollector)
+
+	preparedTransactionsMinAgeFlag = kingpin.Flag(
+		fmt.Sprint(collectorFlagPrefix, preparedTransactionsSubsystem, ".min-age"),
+		"Only report prepared transactions that were prepared longer ago than this.").
+		Default("0s").
+		Duration()
+}
+
+// PGPreparedTransactionsCollector reports two-phase commit transactions that
+// have been prepared but not yet committed or rolled back. These hold locks
+// and pin the xmin horizon until they are resolved.
+type PGPreparedTransactionsCollector struct {
+	log    *slog.Logger
+	minAge time.Duration
+}
+
+func NewPGPreparedTransactionsCollector(config collectorConfig) (Collector, error) {
+	c := &PGPreparedTransactionsCollector{log: config.logger}
+	if preparedTransactionsMinAgeFlag != nil {
+		c.minAge = *preparedTransactionsMinAgeFlag
+	}
+	return c, nil
+}
+
+var (
+	pgPreparedTransactionsByDatabaseDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, preparedTransactionsSubsystem, "by_database"),
+		"Number of prepared transactions older than the minimum age in this database",
+		[]string{"datname"}, nil,
+	)
+	pgPreparedTransactionAgeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "prepared_transaction_age_seconds"),
+		"Seconds since the transaction was prepared",
+		[]string{"gid", "datname"}, nil,
+	)
+
+	pgPreparedTransactionsQuery = `SELECT
+		gid,
+		database,
+		EXTRACT(EPOCH FROM (now() - prepared)) AS age_seconds
+	FROM pg_catalog.pg_prepared_xacts
+	ORDER BY prepared`
+)
+
+func (c PGPreparedTransactionsCollector) Update(ctx context.Context, instance *Instance, ch chan<- prometheus.Metric) error {
+	db := instance.getDB()
+	rows, err := queryWithOverride(ctx, db, preparedTransactionsSubsystem, pgPreparedTransactionsQuery, 3)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	counts := map[string]float64{}
+	for rows.Next() {
+		var gid, datname sql.NullString
+		var age sql.NullFloat64
+		if err := rows.Scan(&gid, &datname, &age); err != nil {
+			return err
+		}
+		if !gid.Valid || !datname.Valid || !age.Valid {
+			continue
+		}
+		if age.Float64 < c.minAge.Seconds() {
+			continue
+		}
+
+		counts[datname.String]++
+		ch <- prometheus.MustNewConstMetric(
+			pgPreparedTransactionAgeDesc,
+			prometheus.GaugeValue, age.Float64,
+			gid.String, datname.String,
+		)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	databases := make([]string, 0, len(counts))
+	for datname := range counts {
+		databases = append(databases, datname)
+	}
+	slices.Sort(databases)
+	for _, datname := range databases {
+		ch <- prometheus.MustNewConstMetric(
+			pgPreparedTransactionsByDatabaseDesc,
+			prometheus.GaugeValue, counts[datname], datname,
+		)
+	}
+	return nil
+}
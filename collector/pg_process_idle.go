@@ -46,8 +46,7 @@ var pgProcessIdleSeconds = prometheus.NewDesc(
 
 func (PGProcessIdleCollector) Update(ctx context.Context, instance *Instance, ch chan<- prometheus.Metric) error {
 	db := instance.getDB()
-	row := db.QueryRowContext(ctx,
-		`WITH
+	query := `WITH
 			metrics AS (
 				SELECT
 				state,
@@ -83,7 +82,7 @@ func (PGProcessIdleCollector) Update(ctx context.Context, instance *Instance, ch
 			ARRAY_AGG(le) AS seconds,
 			ARRAY_AGG(bucket) AS seconds_bucket
 			FROM metrics JOIN buckets USING (state, application_name)
-			GROUP BY 1, 2, 3, 4;`)
+			GROUP BY 1, 2, 3, 4;`
 
 	var state sql.NullString
 	var applicationName sql.NullString
@@ -92,7 +91,7 @@ func (PGProcessIdleCollector) Update(ctx context.Context, instance *Instance, ch
 	var seconds []float64
 	var secondsBucket []int64
 
-	err := row.Scan(&state, &applicationName, &secondsSum, &secondsCount, pq.Array(&seconds), pq.Array(&secondsBucket))
+	err := scanRowWithOverride(ctx, db, processIdleSubsystem, query, nil, &state, &applicationName, &secondsSum, &secondsCount, pq.Array(&seconds), pq.Array(&secondsBucket))
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,84 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const recoverySubsystem = "recovery"
+
+func init() {
+	registerCollector(recoverySubsystem, defaultDisabled, NewPGRecoveryCollector)
+}
+
+// PGRecoveryCollector reports whether the server is a primary or a standby,
+// and on standbys when the last transaction was replayed.
+type PGRecoveryCollector struct {
+	log *slog.Logger
+}
+
+func NewPGRecoveryCollector(config collectorConfig) (Collector, error) {
+	return &PGRecoveryCollector{log: config.logger}, nil
+}
+
+var (
+	pgIsInRecoveryDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "is_in_recovery"),
+		"Whether the server is in recovery, i.e. a standby (1) or a primary (0)",
+		[]string{}, nil,
+	)
+	pgRecoveryLastReplayTimestampDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, recoverySubsystem, "last_replay_timestamp_seconds"),
+		"Commit time of the last transaction replayed during recovery (0 on primaries or before the first replay)",
+		[]string{}, nil,
+	)
+
+	pgRecoveryQuery = `SELECT
+		pg_is_in_recovery() AS is_in_recovery,
+		EXTRACT(EPOCH FROM pg_last_xact_replay_timestamp()) AS last_replay_timestamp`
+)
+
+func (c PGRecoveryCollector) Update(ctx context.Context, instance *Instance, ch chan<- prometheus.Metric) error {
+	db := instance.getDB()
+
+	var isInRecovery sql.NullBool
+	var lastReplay sql.NullFloat64
+	if err := scanRowWithOverride(ctx, db, recoverySubsystem, pgRecoveryQuery, nil, &isInRecovery, &lastReplay); err != nil {
+		return err
+	}
+
+	isInRecoveryMetric := 0.0
+	if isInRecovery.Valid && isInRecovery.Bool {
+		isInRecoveryMetric = 1
+	}
+	lastReplayMetric := 0.0
+	if lastReplay.Valid {
+		lastReplayMetric = lastReplay.Float64
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		pgIsInRecoveryDesc,
+		prometheus.GaugeValue, isInRecoveryMetric,
+	)
+	ch <- prometheus.MustNewConstMetric(
+		pgRecoveryLastReplayTimestampDesc,
+		prometheus.GaugeValue, lastReplayMetric,
+	)
+	return nil
+}
@@ -0,0 +1,87 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package collector
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+)
+
+func TestPGRecoveryCollector(t *testing.T) {
+	tests := []struct {
+		name         string
+		isInRecovery bool
+		lastReplay   any
+		expected     []MetricResult
+	}{
+		{
+			name:         "primary",
+			isInRecovery: false,
+			lastReplay:   nil,
+			expected: []MetricResult{
+				{labels: labelMap{}, value: 0, metricType: dto.MetricType_GAUGE},
+				{labels: labelMap{}, value: 0, metricType: dto.MetricType_GAUGE},
+			},
+		},
+		{
+			name:         "standby",
+			isInRecovery: true,
+			lastReplay:   1685739904.25,
+			expected: []MetricResult{
+				{labels: labelMap{}, value: 1, metricType: dto.MetricType_GAUGE},
+				{labels: labelMap{}, value: 1685739904.25, metricType: dto.MetricType_GAUGE},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("Error opening a stub db connection: %s", err)
+			}
+			defer db.Close()
+
+			inst := &Instance{db: db}
+
+			rows := sqlmock.NewRows([]string{"is_in_recovery", "last_replay_timestamp"}).
+				AddRow(tt.isInRecovery, tt.lastReplay)
+			mock.ExpectQuery(sanitizeQuery(pgRecoveryQuery)).WillReturnRows(rows)
+
+			ch := make(chan prometheus.Metric)
+			go func() {
+				defer close(ch)
+				c := PGRecoveryCollector{}
+
+				if err := c.Update(context.Background(), inst, ch); err != nil {
+					t.Errorf("Error calling PGRecoveryCollector.Update: %s", err)
+				}
+			}()
+
+			convey.Convey("Metrics comparison", t, func() {
+				for _, expect := range tt.expected {
+					m := readMetric(<-ch)
+					convey.So(expect, convey.ShouldResemble, m)
+				}
+			})
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled exceptions: %s", err)
+			}
+		})
+	}
+}
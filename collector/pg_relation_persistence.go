@@ -0,0 +1,83 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const relationPersistenceSubsystem = "relation_persistence"
+
+func init() {
+	registerCollector(relationPersistenceSubsystem, defaultDisabled, NewPGRelationPersistenceCollector)
+}
+
+// PGRelationPersistenceCollector counts unlogged and temporary tables in the
+// connected database. Unlogged tables are truncated after a crash and are not
+// replicated; a growing number of temporary tables usually means sessions are
+// leaking them.
+type PGRelationPersistenceCollector struct {
+	log *slog.Logger
+}
+
+func NewPGRelationPersistenceCollector(config collectorConfig) (Collector, error) {
+	return &PGRelationPersistenceCollector{log: config.logger}, nil
+}
+
+var (
+	pgUnloggedTablesCountDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "unlogged_tables", "count"),
+		"Number of unlogged tables in the database",
+		[]string{"datname"}, nil,
+	)
+	pgTempTablesCountDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "temp_tables", "count"),
+		"Number of temporary tables in the database",
+		[]string{"datname"}, nil,
+	)
+
+	pgRelationPersistenceQuery = `SELECT
+		current_database() AS datname,
+		count(*) FILTER (WHERE relpersistence = 'u') AS unlogged,
+		count(*) FILTER (WHERE relpersistence = 't') AS temp
+	FROM pg_catalog.pg_class
+	WHERE relkind IN ('r', 'p')`
+)
+
+func (c PGRelationPersistenceCollector) Update(ctx context.Context, instance *Instance, ch chan<- prometheus.Metric) error {
+	db := instance.getDB()
+
+	var datname sql.NullString
+	var unlogged, temp sql.NullInt64
+	if err := scanRowWithOverride(ctx, db, relationPersistenceSubsystem, pgRelationPersistenceQuery, nil, &datname, &unlogged, &temp); err != nil {
+		return err
+	}
+	if !datname.Valid {
+		return nil
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		pgUnloggedTablesCountDesc,
+		prometheus.GaugeValue, float64(unlogged.Int64), datname.String,
+	)
+	ch <- prometheus.MustNewConstMetric(
+		pgTempTablesCountDesc,
+		prometheus.GaugeValue, float64(temp.Int64), datname.String,
+	)
+	return nil
+}
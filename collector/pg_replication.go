@@ -76,14 +76,10 @@ var (
 
 func (c *PGReplicationCollector) Update(ctx context.Context, instance *Instance, ch chan<- prometheus.Metric) error {
 	db := instance.getDB()
-	row := db.QueryRowContext(ctx,
-		pgReplicationQuery,
-	)
-
 	var lag float64
 	var isReplica int64
 	var replayAge float64
-	err := row.Scan(&lag, &isReplica, &replayAge)
+	err := scanRowWithOverride(ctx, db, replicationSubsystem, pgReplicationQuery, nil, &lag, &isReplica, &replayAge)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,99 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"sort"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const replicationConnectionsSubsystem = "replication_connections"
+
+func init() {
+	registerCollector(replicationConnectionsSubsystem, defaultDisabled, NewPGReplicationConnectionsCollector)
+}
+
+// PGReplicationConnectionsCollector counts walsender connections by state so
+// that standbys stuck in catchup, or lingering after an unclean disconnect,
+// can be alerted on.
+type PGReplicationConnectionsCollector struct {
+	log *slog.Logger
+}
+
+func NewPGReplicationConnectionsCollector(config collectorConfig) (Collector, error) {
+	return &PGReplicationConnectionsCollector{log: config.logger}, nil
+}
+
+// replicationConnectionStates are always reported, as 0 when no walsender is
+// in that state, so that the series exist on servers without standbys.
+var replicationConnectionStates = []string{"startup", "catchup", "streaming", "backup", "stopping"}
+
+var (
+	pgReplicationConnectionsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "replication", "connections"),
+		"Number of replication connections by walsender state",
+		[]string{"state"}, nil,
+	)
+
+	pgReplicationConnectionsQuery = `SELECT
+		state,
+		count(*) AS connections
+	FROM pg_catalog.pg_stat_replication
+	GROUP BY state`
+)
+
+func (c PGReplicationConnectionsCollector) Update(ctx context.Context, instance *Instance, ch chan<- prometheus.Metric) error {
+	db := instance.getDB()
+	rows, err := queryWithOverride(ctx, db, replicationConnectionsSubsystem, pgReplicationConnectionsQuery, 2)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	counts := make(map[string]float64, len(replicationConnectionStates))
+	for _, state := range replicationConnectionStates {
+		counts[state] = 0
+	}
+	for rows.Next() {
+		var state sql.NullString
+		var connections sql.NullInt64
+		if err := rows.Scan(&state, &connections); err != nil {
+			return err
+		}
+		if !state.Valid || !connections.Valid {
+			continue
+		}
+		counts[state.String] += float64(connections.Int64)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	states := make([]string, 0, len(counts))
+	for state := range counts {
+		states = append(states, state)
+	}
+	sort.Strings(states)
+	for _, state := range states {
+		ch <- prometheus.MustNewConstMetric(
+			pgReplicationConnectionsDesc,
+			prometheus.GaugeValue, counts[state], state,
+		)
+	}
+	return nil
+}
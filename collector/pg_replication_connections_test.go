@@ -0,0 +1,105 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package collector
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+)
+
+func TestPGReplicationConnectionsCollector(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error opening a stub db connection: %s", err)
+	}
+	defer db.Close()
+
+	inst := &Instance{db: db}
+
+	columns := []string{"state", "connections"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("streaming", 2).
+		AddRow("catchup", 1)
+	mock.ExpectQuery(sanitizeQuery(pgReplicationConnectionsQuery)).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		defer close(ch)
+		c := PGReplicationConnectionsCollector{}
+
+		if err := c.Update(context.Background(), inst, ch); err != nil {
+			t.Errorf("Error calling PGReplicationConnectionsCollector.Update: %s", err)
+		}
+	}()
+
+	expected := []MetricResult{
+		{labels: labelMap{"state": "backup"}, value: 0, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"state": "catchup"}, value: 1, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"state": "startup"}, value: 0, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"state": "stopping"}, value: 0, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"state": "streaming"}, value: 2, metricType: dto.MetricType_GAUGE},
+	}
+
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range expected {
+			m := readMetric(<-ch)
+			convey.So(expect, convey.ShouldResemble, m)
+		}
+	})
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
+
+func TestPGReplicationConnectionsCollectorNoStandbys(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error opening a stub db connection: %s", err)
+	}
+	defer db.Close()
+
+	inst := &Instance{db: db}
+
+	mock.ExpectQuery(sanitizeQuery(pgReplicationConnectionsQuery)).
+		WillReturnRows(sqlmock.NewRows([]string{"state", "connections"}))
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		defer close(ch)
+		c := PGReplicationConnectionsCollector{}
+
+		if err := c.Update(context.Background(), inst, ch); err != nil {
+			t.Errorf("Error calling PGReplicationConnectionsCollector.Update: %s", err)
+		}
+	}()
+
+	var metrics []MetricResult
+	for m := range ch {
+		metrics = append(metrics, readMetric(m))
+	}
+
+	convey.Convey("All states reported as zero", t, func() {
+		convey.So(metrics, convey.ShouldHaveLength, len(replicationConnectionStates))
+		for _, m := range metrics {
+			convey.So(m.value, convey.ShouldEqual, 0)
+		}
+	})
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
@@ -109,15 +109,14 @@ var (
 )
 
 func (PGReplicationSlotCollector) Update(ctx context.Context, instance *Instance, ch chan<- prometheus.Metric) error {
-	query := pgReplicationSlotQuery
+	query, columns := pgReplicationSlotQuery, 5
 	abovePG13 := instance.version.GTE(semver.MustParse("13.0.0"))
 	if abovePG13 {
-		query = pgReplicationSlotNewQuery
+		query, columns = pgReplicationSlotNewQuery, 7
 	}
 
 	db := instance.getDB()
-	rows, err := db.QueryContext(ctx,
-		query)
+	rows, err := queryWithOverride(ctx, db, replicationSlotSubsystem, query, columns)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,92 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+
+	"github.com/blang/semver/v4"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const replicationSlotInactivitySubsystem = "replication_slot_inactivity"
+
+func init() {
+	registerCollector(replicationSlotInactivitySubsystem, defaultDisabled, NewPGReplicationSlotInactivityCollector)
+}
+
+// PGReplicationSlotInactivityCollector reports how long each replication slot
+// has been inactive. An inactive slot keeps pinning WAL, so this is the
+// duration an operator has been accumulating WAL for nobody.
+//
+// The inactive_since column was added to pg_replication_slots in PostgreSQL
+// 17; on older servers the collector reports nothing.
+type PGReplicationSlotInactivityCollector struct {
+	log *slog.Logger
+}
+
+func NewPGReplicationSlotInactivityCollector(config collectorConfig) (Collector, error) {
+	return &PGReplicationSlotInactivityCollector{log: config.logger}, nil
+}
+
+var (
+	pgReplicationSlotInactiveSecondsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, replicationSlotSubsystem, "inactive_seconds"),
+		"Seconds since the replication slot became inactive (0 for active slots)",
+		[]string{"slot_name"}, nil,
+	)
+
+	pgReplicationSlotInactivityQuery = `SELECT
+		slot_name,
+		CASE WHEN active THEN 0
+		ELSE EXTRACT(EPOCH FROM (now() - inactive_since))
+		END AS inactive_seconds
+	FROM pg_catalog.pg_replication_slots`
+)
+
+func (c PGReplicationSlotInactivityCollector) Update(ctx context.Context, instance *Instance, ch chan<- prometheus.Metric) error {
+	if instance.version.LT(semver.MustParse("17.0.0")) {
+		return skipUnsupportedVersion(c.log, "pg_replication_slots.inactive_since is not available before PostgreSQL 17")
+	}
+
+	db := instance.getDB()
+	rows, err := queryWithOverride(ctx, db, replicationSlotInactivitySubsystem, pgReplicationSlotInactivityQuery, 2)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var slotName sql.NullString
+		var inactiveSeconds sql.NullFloat64
+		if err := rows.Scan(&slotName, &inactiveSeconds); err != nil {
+			return err
+		}
+		if !slotName.Valid {
+			continue
+		}
+
+		inactiveSecondsMetric := 0.0
+		if inactiveSeconds.Valid {
+			inactiveSecondsMetric = inactiveSeconds.Float64
+		}
+		ch <- prometheus.MustNewConstMetric(
+			pgReplicationSlotInactiveSecondsDesc,
+			prometheus.GaugeValue, inactiveSecondsMetric, slotName.String,
+		)
+	}
+	return rows.Err()
+}
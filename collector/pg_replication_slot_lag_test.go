@@ -0,0 +1,72 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package collector
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/blang/semver/v4"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/promslog"
+	"github.com/smartystreets/goconvey/convey"
+)
+
+func TestPGReplicationSlotLagCollector(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error opening a stub db connection: %s", err)
+	}
+	defer db.Close()
+
+	inst := &Instance{db: db, version: semver.MustParse("16.0.0")}
+
+	columns := []string{"slot_name", "slot_type", "confirmed_flush_lag_bytes", "retained_wal_bytes"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("cdc_orders", "logical", 134217728, 201326592).
+		AddRow("cdc_new", "logical", nil, 16384).
+		AddRow("standby_1", "physical", nil, 8192).
+		AddRow("standby_unused", "physical", nil, nil)
+	mock.ExpectQuery(sanitizeQuery(pgReplicationSlotLagQuery)).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		defer close(ch)
+		c := PGReplicationSlotLagCollector{log: promslog.NewNopLogger()}
+
+		if err := c.Update(context.Background(), inst, ch); err != nil {
+			t.Errorf("Error calling PGReplicationSlotLagCollector.Update: %s", err)
+		}
+	}()
+
+	expected := []MetricResult{
+		{labels: labelMap{"slot_name": "cdc_orders"}, value: 134217728, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"slot_name": "cdc_orders", "slot_type": "logical"}, value: 201326592, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"slot_name": "cdc_new"}, value: 0, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"slot_name": "cdc_new", "slot_type": "logical"}, value: 16384, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"slot_name": "standby_1", "slot_type": "physical"}, value: 8192, metricType: dto.MetricType_GAUGE},
+	}
+
+	convey.Convey("Metrics comparison", t, func() {
+		var metrics []MetricResult
+		for m := range ch {
+			metrics = append(metrics, readMetric(m))
+		}
+		convey.So(metrics, convey.ShouldResemble, expected)
+	})
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const replicationSlotsUsageSubsystem = "replication_slots"
+
+func init() {
+	registerCollector(replicationSlotsUsageSubsystem, defaultDisabled, NewPGReplicationSlotsUsageCollector)
+}
+
+// PGReplicationSlotsUsageCollector reports how many replication slots are in
+// use relative to max_replication_slots. Running out of slots prevents new
+// standbys and subscribers from connecting.
+type PGReplicationSlotsUsageCollector struct {
+	log *slog.Logger
+}
+
+func NewPGReplicationSlotsUsageCollector(config collectorConfig) (Collector, error) {
+	return &PGReplicationSlotsUsageCollector{log: config.logger}, nil
+}
+
+var (
+	pgReplicationSlotsUsedDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, replicationSlotsUsageSubsystem, "used"),
+		"Number of replication slots currently defined",
+		[]string{}, nil,
+	)
+	pgReplicationSlotsMaxDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, replicationSlotsUsageSubsystem, "max"),
+		"Maximum number of replication slots (max_replication_slots)",
+		[]string{}, nil,
+	)
+	pgReplicationSlotsUsedRatioDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, replicationSlotsUsageSubsystem, "used_ratio"),
+		"Ratio of replication slots in use to max_replication_slots",
+		[]string{}, nil,
+	)
+
+	pgReplicationSlotsUsageQuery = `SELECT
+		(SELECT count(*) FROM pg_catalog.pg_replication_slots) AS used,
+		current_setting('max_replication_slots')::int AS max`
+)
+
+func (c PGReplicationSlotsUsageCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- pgReplicationSlotsUsedDesc
+	ch <- pgReplicationSlotsMaxDesc
+	ch <- pgReplicationSlotsUsedRatioDesc
+}
+
+func (c PGReplicationSlotsUsageCollector) Update(ctx context.Context, instance *Instance, ch chan<- prometheus.Metric) error {
+	db := instance.getDB()
+
+	var used, maxSlots sql.NullInt64
+	if err := scanRowWithOverride(ctx, db, replicationSlotsUsageSubsystem, pgReplicationSlotsUsageQuery, nil, &used, &maxSlots); err != nil {
+		return err
+	}
+
+	usedMetric := 0.0
+	if used.Valid {
+		usedMetric = float64(used.Int64)
+	}
+	maxMetric := 0.0
+	if maxSlots.Valid {
+		maxMetric = float64(maxSlots.Int64)
+	}
+	ratioMetric := 0.0
+	if maxMetric > 0 {
+		ratioMetric = usedMetric / maxMetric
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		pgReplicationSlotsUsedDesc,
+		prometheus.GaugeValue, usedMetric,
+	)
+	ch <- prometheus.MustNewConstMetric(
+		pgReplicationSlotsMaxDesc,
+		prometheus.GaugeValue, maxMetric,
+	)
+	ch <- prometheus.MustNewConstMetric(
+		pgReplicationSlotsUsedRatioDesc,
+		prometheus.GaugeValue, ratioMetric,
+	)
+	return nil
+}
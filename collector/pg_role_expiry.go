@@ -0,0 +1,101 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const roleExpirySubsystem = "role_expiry"
+
+func init() {
+	registerCollector(roleExpirySubsystem, defaultDisabled, NewPGRoleExpiryCollector)
+}
+
+type PGRoleExpiryCollector struct {
+	log *slog.Logger
+}
+
+func NewPGRoleExpiryCollector(config collectorConfig) (Collector, error) {
+	return &PGRoleExpiryCollector{log: config.logger}, nil
+}
+
+var (
+	pgRoleValidUntilDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "role", "valid_until_seconds"),
+		"Unix timestamp at which the role password expires (0 if it never expires)",
+		[]string{"rolname"}, nil,
+	)
+	pgRoleExpiredDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "role", "expired"),
+		"Whether the role password has expired (1=yes)",
+		[]string{"rolname"}, nil,
+	)
+
+	// Built-in pg_* roles cannot log in and never carry a password, so they are skipped.
+	pgRoleExpiryQuery = `SELECT
+		rolname,
+		CASE
+			WHEN rolvaliduntil IS NULL OR rolvaliduntil = 'infinity' THEN 0
+			ELSE EXTRACT(EPOCH FROM rolvaliduntil)
+		END AS valid_until,
+		COALESCE(rolvaliduntil < now(), false) AS expired
+	FROM pg_catalog.pg_roles
+	WHERE rolname !~ '^pg_'`
+)
+
+func (c PGRoleExpiryCollector) Update(ctx context.Context, instance *Instance, ch chan<- prometheus.Metric) error {
+	db := instance.getDB()
+	rows, err := queryWithOverride(ctx, db, roleExpirySubsystem, pgRoleExpiryQuery, 3)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var rolname sql.NullString
+		var validUntil sql.NullFloat64
+		var expired sql.NullBool
+		if err := rows.Scan(&rolname, &validUntil, &expired); err != nil {
+			return err
+		}
+
+		if !rolname.Valid {
+			continue
+		}
+
+		validUntilMetric := 0.0
+		if validUntil.Valid {
+			validUntilMetric = validUntil.Float64
+		}
+		ch <- prometheus.MustNewConstMetric(
+			pgRoleValidUntilDesc,
+			prometheus.GaugeValue, validUntilMetric, rolname.String,
+		)
+
+		expiredMetric := 0.0
+		if expired.Valid && expired.Bool {
+			expiredMetric = 1.0
+		}
+		ch <- prometheus.MustNewConstMetric(
+			pgRoleExpiredDesc,
+			prometheus.GaugeValue, expiredMetric, rolname.String,
+		)
+	}
+	return rows.Err()
+}
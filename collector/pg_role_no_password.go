@@ -0,0 +1,83 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const roleNoPasswordSubsystem = "role_no_password"
+
+func init() {
+	// Disabled by default: pg_authid is only readable by superusers, and
+	// every denied scrape is logged as an error by the server.
+	registerCollector(roleNoPasswordSubsystem, defaultDisabled, NewPGRoleNoPasswordCollector)
+}
+
+// PGRoleNoPasswordCollector reports login roles without a stored password.
+// These rely entirely on pg_hba.conf for authentication, which is either
+// intentional external auth or a misconfiguration worth auditing.
+type PGRoleNoPasswordCollector struct {
+	log *slog.Logger
+}
+
+func NewPGRoleNoPasswordCollector(config collectorConfig) (Collector, error) {
+	return &PGRoleNoPasswordCollector{log: config.logger}, nil
+}
+
+var (
+	pgRoleNoPasswordDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "role", "no_password"),
+		"Login role has no stored password (value is always 1)",
+		[]string{"rolname"}, nil,
+	)
+
+	pgRoleNoPasswordQuery = `SELECT rolname
+	FROM pg_catalog.pg_authid
+	WHERE rolcanlogin
+		AND rolpassword IS NULL
+		AND rolname !~ '^pg_'`
+)
+
+func (c PGRoleNoPasswordCollector) Update(ctx context.Context, instance *Instance, ch chan<- prometheus.Metric) error {
+	db := instance.getDB()
+	rows, err := queryWithOverride(ctx, db, roleNoPasswordSubsystem, pgRoleNoPasswordQuery, 1)
+	if err != nil {
+		if isInsufficientPrivilege(err) {
+			c.log.Debug("not permitted to read pg_authid", "err", err)
+			return ErrNoData
+		}
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var rolname sql.NullString
+		if err := rows.Scan(&rolname); err != nil {
+			return err
+		}
+		if !rolname.Valid {
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(
+			pgRoleNoPasswordDesc,
+			prometheus.GaugeValue, 1, rolname.String,
+		)
+	}
+	return rows.Err()
+}
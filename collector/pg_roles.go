@@ -56,9 +56,7 @@ var (
 func (c PGRolesCollector) Update(ctx context.Context, instance *Instance, ch chan<- prometheus.Metric) error {
 	db := instance.getDB()
 	// Query the list of databases
-	rows, err := db.QueryContext(ctx,
-		pgRolesConnectionLimitsQuery,
-	)
+	rows, err := queryWithOverride(ctx, db, rolesSubsystem, pgRolesConnectionLimitsQuery, 2)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,134 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+
+	"github.com/blang/semver/v4"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const sequencesSubsystem = "sequences"
+
+func init() {
+	registerCollector(sequencesSubsystem, defaultDisabled, NewPGSequencesCollector)
+}
+
+// PGSequencesCollector reports how much of its range each non-cycling
+// sequence has used. When a sequence is owned by a smallint or integer
+// column, the column's range is used instead of the sequence's, since
+// a bigint sequence feeding an integer column fails once the column is full.
+type PGSequencesCollector struct {
+	log *slog.Logger
+}
+
+func NewPGSequencesCollector(config collectorConfig) (Collector, error) {
+	return &PGSequencesCollector{log: config.logger}, nil
+}
+
+var (
+	pgSequenceConsumedRatioDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "sequence", "consumed_ratio"),
+		"Fraction of the sequence's usable range that has been consumed",
+		[]string{"datname", "schemaname", "sequencename", "data_type"}, nil,
+	)
+	pgSequenceRemainingValuesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "sequence", "remaining_values"),
+		"Number of values the sequence can still hand out before it is exhausted",
+		[]string{"datname", "schemaname", "sequencename", "data_type"}, nil,
+	)
+
+	pgSequencesQuery = `SELECT
+		current_database() AS datname,
+		schemaname,
+		sequencename,
+		data_type,
+		CASE WHEN increment_by > 0
+			THEN (last_value - min_value) / (max_value - min_value)
+			ELSE (max_value - last_value) / (max_value - min_value)
+		END AS consumed_ratio,
+		CASE WHEN increment_by > 0
+			THEN floor((max_value - last_value) / abs(increment_by))
+			ELSE floor((last_value - min_value) / abs(increment_by))
+		END AS remaining_values
+	FROM (
+		SELECT
+			s.schemaname,
+			s.sequencename,
+			coalesce(format_type(a.atttypid, NULL), format_type(s.data_type, NULL)) AS data_type,
+			s.increment_by::numeric AS increment_by,
+			s.last_value::numeric AS last_value,
+			GREATEST(s.min_value, CASE a.atttypid
+				WHEN 'int2'::regtype THEN -32768
+				WHEN 'int4'::regtype THEN -2147483648
+				ELSE s.min_value END)::numeric AS min_value,
+			LEAST(s.max_value, CASE a.atttypid
+				WHEN 'int2'::regtype THEN 32767
+				WHEN 'int4'::regtype THEN 2147483647
+				ELSE s.max_value END)::numeric AS max_value
+		FROM pg_catalog.pg_sequences s
+		JOIN pg_catalog.pg_namespace n ON n.nspname = s.schemaname
+		JOIN pg_catalog.pg_class c ON c.relnamespace = n.oid AND c.relname = s.sequencename
+		LEFT JOIN pg_catalog.pg_depend d ON d.classid = 'pg_catalog.pg_class'::regclass
+			AND d.objid = c.oid
+			AND d.refclassid = 'pg_catalog.pg_class'::regclass
+			AND d.deptype IN ('a', 'i')
+		LEFT JOIN pg_catalog.pg_attribute a ON a.attrelid = d.refobjid AND a.attnum = d.refobjsubid
+		WHERE NOT s.cycle
+			AND s.last_value IS NOT NULL
+	) AS sequences`
+)
+
+func (c PGSequencesCollector) Update(ctx context.Context, instance *Instance, ch chan<- prometheus.Metric) error {
+	if instance.version.LT(semver.MustParse("10.0.0")) {
+		return skipUnsupportedVersion(c.log, "pg_sequences is not available before PostgreSQL 10")
+	}
+
+	db := instance.getDB()
+	rows, err := queryWithOverride(ctx, db, sequencesSubsystem, pgSequencesQuery, 6)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var datname, schemaname, sequencename, dataType sql.NullString
+		var consumedRatio, remainingValues sql.NullFloat64
+		if err := rows.Scan(&datname, &schemaname, &sequencename, &dataType, &consumedRatio, &remainingValues); err != nil {
+			return err
+		}
+
+		if !datname.Valid || !schemaname.Valid || !sequencename.Valid {
+			continue
+		}
+		labels := []string{datname.String, schemaname.String, sequencename.String, dataType.String}
+
+		if consumedRatio.Valid {
+			ch <- prometheus.MustNewConstMetric(
+				pgSequenceConsumedRatioDesc,
+				prometheus.GaugeValue, consumedRatio.Float64, labels...,
+			)
+		}
+		if remainingValues.Valid {
+			ch <- prometheus.MustNewConstMetric(
+				pgSequenceRemainingValuesDesc,
+				prometheus.GaugeValue, remainingValues.Float64, labels...,
+			)
+		}
+	}
+	return rows.Err()
+}
@@ -0,0 +1,81 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const settingsNonDefaultSubsystem = "settings_non_default"
+
+func init() {
+	registerCollector(settingsNonDefaultSubsystem, defaultDisabled, NewPGSettingsNonDefaultCollector)
+}
+
+// PGSettingsNonDefaultCollector reports every server setting that has been
+// changed from its built-in default, so configuration drift across a fleet
+// shows up as differing label values. Settings whose source is the client
+// or the session only describe the exporter's own connection and are left
+// out.
+type PGSettingsNonDefaultCollector struct {
+	log *slog.Logger
+}
+
+func NewPGSettingsNonDefaultCollector(config collectorConfig) (Collector, error) {
+	return &PGSettingsNonDefaultCollector{log: config.logger}, nil
+}
+
+var (
+	pgSettingsNonDefaultDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "settings", "non_default"),
+		"Setting differs from its built-in default (value is always 1)",
+		[]string{"name", "setting", "source"}, nil,
+	)
+
+	pgSettingsNonDefaultQuery = `SELECT
+		name,
+		setting,
+		source
+	FROM pg_catalog.pg_settings
+	WHERE source NOT IN ('default', 'client', 'session')
+	ORDER BY name`
+)
+
+func (c PGSettingsNonDefaultCollector) Update(ctx context.Context, instance *Instance, ch chan<- prometheus.Metric) error {
+	db := instance.getDB()
+	rows, err := queryWithOverride(ctx, db, settingsNonDefaultSubsystem, pgSettingsNonDefaultQuery, 3)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var name, setting, source sql.NullString
+		if err := rows.Scan(&name, &setting, &source); err != nil {
+			return err
+		}
+		if !name.Valid {
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(
+			pgSettingsNonDefaultDesc,
+			prometheus.GaugeValue, 1, name.String, setting.String, source.String,
+		)
+	}
+	return rows.Err()
+}
@@ -0,0 +1,91 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+
+	"github.com/blang/semver/v4"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const settingsPendingRestartSubsystem = "settings_pending_restart"
+
+func init() {
+	registerCollector(settingsPendingRestartSubsystem, defaultDisabled, NewPGSettingsPendingRestartCollector)
+}
+
+type PGSettingsPendingRestartCollector struct {
+	log *slog.Logger
+}
+
+func NewPGSettingsPendingRestartCollector(config collectorConfig) (Collector, error) {
+	return &PGSettingsPendingRestartCollector{log: config.logger}, nil
+}
+
+var (
+	pgSettingsPendingRestartDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "settings", "pending_restart"),
+		"Setting has been changed in the configuration file but requires a restart to take effect (value is always 1)",
+		[]string{"name"}, nil,
+	)
+	pgSettingsPendingRestartCountDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "settings", "pending_restart_count"),
+		"Number of settings that have been changed in the configuration file but require a restart to take effect",
+		[]string{}, nil,
+	)
+
+	pgSettingsPendingRestartQuery = "SELECT name FROM pg_catalog.pg_settings WHERE pending_restart ORDER BY name"
+)
+
+func (c PGSettingsPendingRestartCollector) Update(ctx context.Context, instance *Instance, ch chan<- prometheus.Metric) error {
+	// pending_restart was added to pg_settings in PostgreSQL 9.5.
+	if instance.version.LT(semver.MustParse("9.5.0")) {
+		return skipUnsupportedVersion(c.log, "settings_pending_restart collector is not available on PostgreSQL < 9.5, skipping")
+	}
+
+	db := instance.getDB()
+	rows, err := queryWithOverride(ctx, db, settingsPendingRestartSubsystem, pgSettingsPendingRestartQuery, 1)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	pending := 0
+	for rows.Next() {
+		var name sql.NullString
+		if err := rows.Scan(&name); err != nil {
+			return err
+		}
+		if !name.Valid {
+			continue
+		}
+		pending++
+		ch <- prometheus.MustNewConstMetric(
+			pgSettingsPendingRestartDesc,
+			prometheus.GaugeValue, 1, name.String,
+		)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		pgSettingsPendingRestartCountDesc,
+		prometheus.GaugeValue, float64(pending),
+	)
+	return nil
+}
@@ -50,10 +50,8 @@ var (
 
 func (c *PGSharedPreloadLibrariesCollector) Update(ctx context.Context, instance *Instance, ch chan<- prometheus.Metric) error {
 	db := instance.getDB()
-	row := db.QueryRowContext(ctx, pgSharedPreloadLibrariesQuery)
-
 	var setting sql.NullString
-	err := row.Scan(&setting)
+	err := scanRowWithOverride(ctx, db, sharedPreloadLibrariesSubsystem, pgSharedPreloadLibrariesQuery, nil, &setting)
 	if err != nil {
 		return err
 	}
@@ -55,8 +55,7 @@ var (
 
 func (PGStatActivityAutovacuumCollector) Update(ctx context.Context, instance *Instance, ch chan<- prometheus.Metric) error {
 	db := instance.getDB()
-	rows, err := db.QueryContext(ctx,
-		statActivityAutovacuumQuery)
+	rows, err := queryWithOverride(ctx, db, statActivityAutovacuumSubsystem, statActivityAutovacuumQuery, 2)
 
 	if err != nil {
 		return err
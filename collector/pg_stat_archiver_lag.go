@@ -162,12 +162,10 @@ func bytesBetweenLSN(currentLSN, archivedLSN LSN) LSN {
 
 func (PGStatArchiverLagCollector) Update(ctx context.Context, instance *Instance, ch chan<- prometheus.Metric) error {
 	db := instance.getDB()
-	row := db.QueryRowContext(ctx, statArchiverLagQuery)
-
 	var lastArchivedWal sql.NullString
 	var currentLSN sql.NullString
 
-	err := row.Scan(&lastArchivedWal, &currentLSN)
+	err := scanRowWithOverride(ctx, db, archiverLagSubsystem, statArchiverLagQuery, nil, &lastArchivedWal, &currentLSN)
 	if err != nil {
 		// If no rows found (no WAL segments archived yet), return 0 lag
 		if err == sql.ErrNoRows {
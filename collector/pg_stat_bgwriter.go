@@ -127,12 +127,10 @@ var (
 func (PGStatBGWriterCollector) Update(ctx context.Context, instance *Instance, ch chan<- prometheus.Metric) error {
 	if instance.version.GE(semver.MustParse("17.0.0")) {
 		db := instance.getDB()
-		row := db.QueryRowContext(ctx, statBGWriterQueryAfter17)
-
 		var bc, mwc, ba sql.NullInt64
 		var sr sql.NullTime
 
-		err := row.Scan(&bc, &mwc, &ba, &sr)
+		err := scanRowWithOverride(ctx, db, bgWriterSubsystem, statBGWriterQueryAfter17, nil, &bc, &mwc, &ba, &sr)
 		if err != nil {
 			return err
 		}
@@ -175,13 +173,11 @@ func (PGStatBGWriterCollector) Update(ctx context.Context, instance *Instance, c
 		)
 	} else {
 		db := instance.getDB()
-		row := db.QueryRowContext(ctx, statBGWriterQueryBefore17)
-
 		var cpt, cpr, bcp, bc, mwc, bb, bbf, ba sql.NullInt64
 		var cpwt, cpst sql.NullFloat64
 		var sr sql.NullTime
 
-		err := row.Scan(&cpt, &cpr, &cpwt, &cpst, &bcp, &bc, &mwc, &bb, &bbf, &ba, &sr)
+		err := scanRowWithOverride(ctx, db, bgWriterSubsystem, statBGWriterQueryBefore17, nil, &cpt, &cpr, &cpwt, &cpst, &bcp, &bc, &mwc, &bb, &bbf, &ba, &sr)
 		if err != nil {
 			return err
 		}
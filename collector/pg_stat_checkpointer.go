@@ -112,12 +112,9 @@ func (c PGStatCheckpointerCollector) Update(ctx context.Context, instance *Insta
 
 	before17 := instance.version.LT(semver.MustParse("17.0.0"))
 	if before17 {
-		c.log.Warn("pg_stat_checkpointer collector is not available on PostgreSQL < 17.0.0, skipping")
-		return nil
+		return skipUnsupportedVersion(c.log, "pg_stat_checkpointer collector is not available on PostgreSQL < 17.0.0, skipping")
 	}
 
-	row := db.QueryRowContext(ctx, statCheckpointerQuery)
-
 	// num_timed           = nt  = bigint
 	// num_requested       = nr  = bigint
 	// restartpoints_timed = rpt = bigint
@@ -132,7 +129,7 @@ func (c PGStatCheckpointerCollector) Update(ctx context.Context, instance *Insta
 	var wt, st sql.NullFloat64
 	var sr sql.NullTime
 
-	err := row.Scan(&nt, &nr, &rpt, &rpr, &rpd, &wt, &st, &bw, &sr)
+	err := scanRowWithOverride(ctx, db, statCheckpointerSubsystem, statCheckpointerQuery, nil, &nt, &nr, &rpt, &rpr, &rpd, &wt, &st, &bw, &sr)
 	if err != nil {
 		return err
 	}
@@ -18,6 +18,7 @@ import (
 	"database/sql"
 	"fmt"
 	"log/slog"
+	"slices"
 	"strings"
 
 	"github.com/blang/semver/v4"
@@ -31,11 +32,19 @@ func init() {
 }
 
 type PGStatDatabaseCollector struct {
-	log *slog.Logger
+	log               *slog.Logger
+	excludedDatabases []string
 }
 
 func NewPGStatDatabaseCollector(config collectorConfig) (Collector, error) {
-	return &PGStatDatabaseCollector{log: config.logger}, nil
+	exclude := config.excludeDatabases
+	if exclude == nil {
+		exclude = []string{}
+	}
+	return &PGStatDatabaseCollector{
+		log:               config.logger,
+		excludedDatabases: exclude,
+	}, nil
 }
 
 var (
@@ -220,7 +229,7 @@ var (
 )
 
 func statDatabaseQuery(columns []string) string {
-	return fmt.Sprintf("SELECT %s FROM pg_stat_database;", strings.Join(columns, ","))
+	return terminateQuery(fmt.Sprintf("SELECT %s FROM pg_stat_database", strings.Join(columns, ",")))
 }
 
 func (c *PGStatDatabaseCollector) Update(ctx context.Context, instance *Instance, ch chan<- prometheus.Metric) error {
@@ -253,9 +262,7 @@ func (c *PGStatDatabaseCollector) Update(ctx context.Context, instance *Instance
 		columns = append(columns, "active_time")
 	}
 
-	rows, err := db.QueryContext(ctx,
-		statDatabaseQuery(columns),
-	)
+	rows, err := queryWithOverride(ctx, db, statDatabaseSubsystem, statDatabaseQuery(columns), len(columns))
 	if err != nil {
 		return err
 	}
@@ -305,6 +312,9 @@ func (c *PGStatDatabaseCollector) Update(ctx context.Context, instance *Instance
 			c.log.Debug("Skipping collecting metric because it has no datname")
 			continue
 		}
+		if slices.Contains(c.excludedDatabases, datname.String) {
+			continue
+		}
 		if !numBackends.Valid {
 			c.log.Debug("Skipping collecting metric because it has no numbackends")
 			continue
@@ -0,0 +1,114 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"slices"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const statDatabaseIOTimingSubsystem = "stat_database_io_timing"
+
+func init() {
+	registerCollector(statDatabaseIOTimingSubsystem, defaultDisabled, NewPGStatDatabaseIOTimingCollector)
+}
+
+// PGStatDatabaseIOTimingCollector reports the time each database spent
+// reading and writing data file blocks, in seconds. The underlying counters
+// only advance while track_io_timing is on, so the collector reports no data
+// when it is off rather than a misleading set of zeros.
+type PGStatDatabaseIOTimingCollector struct {
+	log               *slog.Logger
+	excludedDatabases []string
+}
+
+func NewPGStatDatabaseIOTimingCollector(config collectorConfig) (Collector, error) {
+	exclude := config.excludeDatabases
+	if exclude == nil {
+		exclude = []string{}
+	}
+	return &PGStatDatabaseIOTimingCollector{
+		log:               config.logger,
+		excludedDatabases: exclude,
+	}, nil
+}
+
+var (
+	statDatabaseBlkReadTimeSecondsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, statDatabaseSubsystem, "blk_read_time_seconds_total"),
+		"Time spent reading data file blocks by backends in this database, in seconds",
+		[]string{"datname"}, nil,
+	)
+	statDatabaseBlkWriteTimeSecondsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, statDatabaseSubsystem, "blk_write_time_seconds_total"),
+		"Time spent writing data file blocks by backends in this database, in seconds",
+		[]string{"datname"}, nil,
+	)
+
+	statDatabaseTrackIOTimingQuery = "SELECT current_setting('track_io_timing')::bool"
+
+	statDatabaseIOTimingQuery = `SELECT
+		datname,
+		blk_read_time,
+		blk_write_time
+	FROM pg_catalog.pg_stat_database
+	WHERE datname IS NOT NULL`
+)
+
+func (c PGStatDatabaseIOTimingCollector) Update(ctx context.Context, instance *Instance, ch chan<- prometheus.Metric) error {
+	db := instance.getDB()
+
+	var trackIOTiming bool
+	if err := db.QueryRowContext(ctx, statDatabaseTrackIOTimingQuery).Scan(&trackIOTiming); err != nil {
+		return err
+	}
+	if !trackIOTiming {
+		c.log.Debug("track_io_timing is off, block read and write times are not tracked")
+		return ErrNoData
+	}
+
+	rows, err := queryWithOverride(ctx, db, statDatabaseIOTimingSubsystem, statDatabaseIOTimingQuery, 3)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var datname sql.NullString
+		var readTime, writeTime sql.NullFloat64
+		if err := rows.Scan(&datname, &readTime, &writeTime); err != nil {
+			return err
+		}
+		if !datname.Valid || slices.Contains(c.excludedDatabases, datname.String) {
+			continue
+		}
+
+		// pg_stat_database reports these times in milliseconds.
+		ch <- prometheus.MustNewConstMetric(
+			statDatabaseBlkReadTimeSecondsDesc,
+			prometheus.CounterValue, readTime.Float64/1000,
+			datname.String,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			statDatabaseBlkWriteTimeSecondsDesc,
+			prometheus.CounterValue, writeTime.Float64/1000,
+			datname.String,
+		)
+	}
+	return rows.Err()
+}
@@ -0,0 +1,108 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package collector
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/promslog"
+	"github.com/smartystreets/goconvey/convey"
+)
+
+func TestPGStatDatabaseIOTimingCollector(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error opening a stub db connection: %s", err)
+	}
+	defer db.Close()
+
+	inst := &Instance{db: db}
+
+	mock.ExpectQuery(sanitizeQuery(statDatabaseTrackIOTimingQuery)).
+		WillReturnRows(sqlmock.NewRows([]string{"track_io_timing"}).AddRow(true))
+
+	columns := []string{"datname", "blk_read_time", "blk_write_time"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("postgres", 1500, 250.5).
+		AddRow("excluded", 10, 10)
+	mock.ExpectQuery(sanitizeQuery(statDatabaseIOTimingQuery)).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		defer close(ch)
+		c := PGStatDatabaseIOTimingCollector{
+			log:               promslog.NewNopLogger(),
+			excludedDatabases: []string{"excluded"},
+		}
+
+		if err := c.Update(context.Background(), inst, ch); err != nil {
+			t.Errorf("Error calling PGStatDatabaseIOTimingCollector.Update: %s", err)
+		}
+	}()
+
+	expected := []MetricResult{
+		{labels: labelMap{"datname": "postgres"}, value: 1.5, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"datname": "postgres"}, value: 0.2505, metricType: dto.MetricType_COUNTER},
+	}
+
+	convey.Convey("Metrics comparison", t, func() {
+		var metrics []MetricResult
+		for m := range ch {
+			metrics = append(metrics, readMetric(m))
+		}
+		convey.So(metrics, convey.ShouldResemble, expected)
+	})
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
+
+func TestPGStatDatabaseIOTimingCollectorTrackingOff(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error opening a stub db connection: %s", err)
+	}
+	defer db.Close()
+
+	inst := &Instance{db: db}
+
+	mock.ExpectQuery(sanitizeQuery(statDatabaseTrackIOTimingQuery)).
+		WillReturnRows(sqlmock.NewRows([]string{"track_io_timing"}).AddRow(false))
+
+	ch := make(chan prometheus.Metric)
+	errCh := make(chan error, 1)
+	go func() {
+		defer close(ch)
+		c := PGStatDatabaseIOTimingCollector{log: promslog.NewNopLogger()}
+		errCh <- c.Update(context.Background(), inst, ch)
+	}()
+
+	var metrics []MetricResult
+	for m := range ch {
+		metrics = append(metrics, readMetric(m))
+	}
+
+	if err := <-errCh; err != ErrNoData {
+		t.Errorf("Expected ErrNoData, got: %v", err)
+	}
+	convey.Convey("No metrics emitted", t, func() {
+		convey.So(metrics, convey.ShouldBeEmpty)
+	})
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
@@ -528,3 +528,83 @@ func TestPGStatDatabaseCollectorTestNilStatReset(t *testing.T) {
 		t.Errorf("there were unfulfilled exceptions: %s", err)
 	}
 }
+
+func TestPGStatDatabaseCollectorDeadlocksAndConflicts(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error opening a stub db connection: %s", err)
+	}
+	defer db.Close()
+
+	inst := &Instance{db: db, version: semver.MustParse("13.0.0")}
+
+	columns := []string{
+		"datid",
+		"datname",
+		"numbackends",
+		"xact_commit",
+		"xact_rollback",
+		"blks_read",
+		"blks_hit",
+		"tup_returned",
+		"tup_fetched",
+		"tup_inserted",
+		"tup_updated",
+		"tup_deleted",
+		"conflicts",
+		"temp_files",
+		"temp_bytes",
+		"deadlocks",
+		"blk_read_time",
+		"blk_write_time",
+		"stats_reset",
+	}
+
+	rows := sqlmock.NewRows(columns).
+		AddRow("0", nil, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 99, 0, 0, nil).
+		AddRow("16384", "app", 3, 10, 1, 5, 50, 100, 40, 8, 4, 2, 7, 0, 0, 3, 0, 0, nil).
+		AddRow("16385", "reporting", 1, 20, 0, 6, 60, 200, 80, 0, 0, 0, 2, 1, 1024, 11, 0, 0, nil).
+		AddRow("16386", "template_ignored", 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 5, 0, 0, 42, 0, 0, nil)
+
+	mock.ExpectQuery(sanitizeQuery(statDatabaseQuery(columns))).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		defer close(ch)
+		c := PGStatDatabaseCollector{
+			log:               promslog.NewNopLogger().With("collector", "pg_stat_database"),
+			excludedDatabases: []string{"template_ignored"},
+		}
+
+		if err := c.Update(context.Background(), inst, ch); err != nil {
+			t.Errorf("Error calling PGStatDatabaseCollector.Update: %s", err)
+		}
+	}()
+
+	// The excluded database is skipped entirely, as in stat_database_io_timing.
+	var counters []MetricResult
+	numBackends := map[string]bool{}
+	for m := range ch {
+		switch m.Desc() {
+		case statDatabaseConflicts, statDatabaseDeadlocks:
+			counters = append(counters, readMetric(m))
+		case statDatabaseNumbackends:
+			numBackends[readMetric(m).labels["datname"]] = true
+		}
+	}
+
+	expected := []MetricResult{
+		{labels: labelMap{"datid": "16384", "datname": "app"}, metricType: dto.MetricType_COUNTER, value: 7},
+		{labels: labelMap{"datid": "16384", "datname": "app"}, metricType: dto.MetricType_COUNTER, value: 3},
+		{labels: labelMap{"datid": "16385", "datname": "reporting"}, metricType: dto.MetricType_COUNTER, value: 2},
+		{labels: labelMap{"datid": "16385", "datname": "reporting"}, metricType: dto.MetricType_COUNTER, value: 11},
+	}
+
+	convey.Convey("Metrics comparison", t, func() {
+		convey.So(counters, convey.ShouldResemble, expected)
+		convey.So(numBackends, convey.ShouldResemble, map[string]bool{"app": true, "reporting": true})
+	})
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+
+	"github.com/blang/semver/v4"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const statIOSubsystem = "stat_io"
+
+func init() {
+	registerCollector(statIOSubsystem, defaultDisabled, NewPGStatIOCollector)
+}
+
+// PGStatIOCollector reports cluster-wide I/O statistics from pg_stat_io,
+// broken down by backend type, target object and I/O context.
+type PGStatIOCollector struct {
+	log *slog.Logger
+}
+
+func NewPGStatIOCollector(config collectorConfig) (Collector, error) {
+	return &PGStatIOCollector{log: config.logger}, nil
+}
+
+var (
+	statIOLabels = []string{"backend_type", "object", "context"}
+
+	statIOReadsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, statIOSubsystem, "reads_total"),
+		"Number of read operations",
+		statIOLabels, nil,
+	)
+	statIOWritesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, statIOSubsystem, "writes_total"),
+		"Number of write operations",
+		statIOLabels, nil,
+	)
+	statIOExtendsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, statIOSubsystem, "extends_total"),
+		"Number of relation extend operations",
+		statIOLabels, nil,
+	)
+	statIOHitsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, statIOSubsystem, "hits_total"),
+		"Number of times a desired block was found in a shared buffer",
+		statIOLabels, nil,
+	)
+	statIOEvictionsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, statIOSubsystem, "evictions_total"),
+		"Number of times a block has been written out from a shared or local buffer to make it available for another use",
+		statIOLabels, nil,
+	)
+	statIOFsyncsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, statIOSubsystem, "fsyncs_total"),
+		"Number of fsync calls",
+		statIOLabels, nil,
+	)
+
+	statIOQuery = `SELECT
+		backend_type,
+		object,
+		context,
+		reads,
+		writes,
+		extends,
+		hits,
+		evictions,
+		fsyncs
+	FROM pg_catalog.pg_stat_io`
+)
+
+func (c PGStatIOCollector) Update(ctx context.Context, instance *Instance, ch chan<- prometheus.Metric) error {
+	if instance.version.LT(semver.MustParse("16.0.0")) {
+		return skipUnsupportedVersion(c.log, "pg_stat_io collector is not available on PostgreSQL < 16, skipping")
+	}
+
+	db := instance.getDB()
+	rows, err := queryWithOverride(ctx, db, statIOSubsystem, statIOQuery, 9)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var backendType, object, ioContext sql.NullString
+		var reads, writes, extends, hits, evictions, fsyncs sql.NullInt64
+		if err := rows.Scan(&backendType, &object, &ioContext, &reads, &writes, &extends, &hits, &evictions, &fsyncs); err != nil {
+			return err
+		}
+		if !backendType.Valid || !object.Valid || !ioContext.Valid {
+			continue
+		}
+
+		// Operations that never happen for a combination, such as fsyncs
+		// of temporary relations, are NULL rather than 0 and are omitted.
+		for _, counter := range []struct {
+			desc  *prometheus.Desc
+			value sql.NullInt64
+		}{
+			{statIOReadsDesc, reads},
+			{statIOWritesDesc, writes},
+			{statIOExtendsDesc, extends},
+			{statIOHitsDesc, hits},
+			{statIOEvictionsDesc, evictions},
+			{statIOFsyncsDesc, fsyncs},
+		} {
+			if !counter.value.Valid {
+				continue
+			}
+			ch <- prometheus.MustNewConstMetric(
+				counter.desc,
+				prometheus.CounterValue, float64(counter.value.Int64),
+				backendType.String, object.String, ioContext.String,
+			)
+		}
+	}
+	return rows.Err()
+}
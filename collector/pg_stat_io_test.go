@@ -0,0 +1,104 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package collector
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/blang/semver/v4"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+)
+
+func TestPGStatIOCollector(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error opening a stub db connection: %s", err)
+	}
+	defer db.Close()
+
+	inst := &Instance{db: db, version: semver.MustParse("16.0.0")}
+
+	columns := []string{"backend_type", "object", "context", "reads", "writes", "extends", "hits", "evictions", "fsyncs"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("client backend", "relation", "normal", 120, 30, 8, 45000, 12, 0).
+		AddRow("client backend", "temp relation", "normal", 4, 2, 1, 60, 0, nil)
+	mock.ExpectQuery(sanitizeQuery(statIOQuery)).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		defer close(ch)
+		c := PGStatIOCollector{}
+
+		if err := c.Update(context.Background(), inst, ch); err != nil {
+			t.Errorf("Error calling PGStatIOCollector.Update: %s", err)
+		}
+	}()
+
+	relation := labelMap{"backend_type": "client backend", "object": "relation", "context": "normal"}
+	temp := labelMap{"backend_type": "client backend", "object": "temp relation", "context": "normal"}
+	expected := []MetricResult{
+		{labels: relation, value: 120, metricType: dto.MetricType_COUNTER},
+		{labels: relation, value: 30, metricType: dto.MetricType_COUNTER},
+		{labels: relation, value: 8, metricType: dto.MetricType_COUNTER},
+		{labels: relation, value: 45000, metricType: dto.MetricType_COUNTER},
+		{labels: relation, value: 12, metricType: dto.MetricType_COUNTER},
+		{labels: relation, value: 0, metricType: dto.MetricType_COUNTER},
+		{labels: temp, value: 4, metricType: dto.MetricType_COUNTER},
+		{labels: temp, value: 2, metricType: dto.MetricType_COUNTER},
+		{labels: temp, value: 1, metricType: dto.MetricType_COUNTER},
+		{labels: temp, value: 60, metricType: dto.MetricType_COUNTER},
+		{labels: temp, value: 0, metricType: dto.MetricType_COUNTER},
+	}
+
+	convey.Convey("Metrics comparison", t, func() {
+		var metrics []MetricResult
+		for m := range ch {
+			metrics = append(metrics, readMetric(m))
+		}
+		convey.So(metrics, convey.ShouldResemble, expected)
+	})
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
+
+func TestPGStatIOCollectorBeforePG16(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error opening a stub db connection: %s", err)
+	}
+	defer db.Close()
+
+	inst := &Instance{db: db, version: semver.MustParse("15.6.0")}
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		defer close(ch)
+		c := PGStatIOCollector{}
+
+		if err := c.Update(context.Background(), inst, ch); err != nil {
+			t.Errorf("Error calling PGStatIOCollector.Update: %s", err)
+		}
+	}()
+	for range ch {
+		t.Errorf("expected no metrics before PostgreSQL 16")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
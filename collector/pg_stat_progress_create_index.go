@@ -0,0 +1,180 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+
+	"github.com/blang/semver/v4"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const progressCreateIndexSubsystem = "stat_progress_create_index"
+
+func init() {
+	registerCollector(progressCreateIndexSubsystem, defaultDisabled, NewPGStatProgressCreateIndexCollector)
+}
+
+// PGStatProgressCreateIndexCollector reports the progress of running CREATE
+// INDEX and REINDEX commands, so long CONCURRENTLY builds can be followed
+// through their phases.
+type PGStatProgressCreateIndexCollector struct {
+	log *slog.Logger
+}
+
+func NewPGStatProgressCreateIndexCollector(config collectorConfig) (Collector, error) {
+	return &PGStatProgressCreateIndexCollector{log: config.logger}, nil
+}
+
+var (
+	statProgressCreateIndexLabels = []string{"datname", "relname", "indexrelname"}
+
+	statProgressCreateIndexPhase = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, progressCreateIndexSubsystem, "phase"),
+		"Command and current phase of the index build (value is always 1). Phase names depend on the index access method.",
+		[]string{"datname", "relname", "indexrelname", "command", "phase"},
+		nil,
+	)
+	statProgressCreateIndexLockersTotal = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, progressCreateIndexSubsystem, "lockers_total"),
+		"Number of lockers to wait for in the current waiting phase.",
+		statProgressCreateIndexLabels,
+		nil,
+	)
+	statProgressCreateIndexLockersDone = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, progressCreateIndexSubsystem, "lockers_done"),
+		"Number of lockers already waited for.",
+		statProgressCreateIndexLabels,
+		nil,
+	)
+	statProgressCreateIndexBlocksTotal = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, progressCreateIndexSubsystem, "blocks_total"),
+		"Total number of blocks to be processed in the current phase.",
+		statProgressCreateIndexLabels,
+		nil,
+	)
+	statProgressCreateIndexBlocksDone = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, progressCreateIndexSubsystem, "blocks_done"),
+		"Number of blocks already processed in the current phase.",
+		statProgressCreateIndexLabels,
+		nil,
+	)
+	statProgressCreateIndexTuplesTotal = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, progressCreateIndexSubsystem, "tuples_total"),
+		"Total number of tuples to be processed in the current phase.",
+		statProgressCreateIndexLabels,
+		nil,
+	)
+	statProgressCreateIndexTuplesDone = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, progressCreateIndexSubsystem, "tuples_done"),
+		"Number of tuples already processed in the current phase.",
+		statProgressCreateIndexLabels,
+		nil,
+	)
+
+	statProgressCreateIndexQuery = `SELECT
+		datname,
+		relid::regclass::text AS relname,
+		NULLIF(index_relid, 0)::regclass::text AS indexrelname,
+		command,
+		phase,
+		lockers_total,
+		lockers_done,
+		blocks_total,
+		blocks_done,
+		tuples_total,
+		tuples_done
+	FROM pg_catalog.pg_stat_progress_create_index`
+)
+
+func (c PGStatProgressCreateIndexCollector) Update(ctx context.Context, instance *Instance, ch chan<- prometheus.Metric) error {
+	if instance.version.LT(semver.MustParse("12.0.0")) {
+		return skipUnsupportedVersion(c.log, "pg_stat_progress_create_index is not available before PostgreSQL 12")
+	}
+
+	db := instance.getDB()
+	rows, err := queryWithOverride(ctx, db, progressCreateIndexSubsystem, statProgressCreateIndexQuery, 11)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			datname      sql.NullString
+			relname      sql.NullString
+			indexrelname sql.NullString
+			command      sql.NullString
+			phase        sql.NullString
+			lockersTotal sql.NullInt64
+			lockersDone  sql.NullInt64
+			blocksTotal  sql.NullInt64
+			blocksDone   sql.NullInt64
+			tuplesTotal  sql.NullInt64
+			tuplesDone   sql.NullInt64
+		)
+		if err := rows.Scan(
+			&datname,
+			&relname,
+			&indexrelname,
+			&command,
+			&phase,
+			&lockersTotal,
+			&lockersDone,
+			&blocksTotal,
+			&blocksDone,
+			&tuplesTotal,
+			&tuplesDone,
+		); err != nil {
+			return err
+		}
+
+		datnameLabel := "unknown"
+		if datname.Valid {
+			datnameLabel = datname.String
+		}
+		relnameLabel := "unknown"
+		if relname.Valid {
+			relnameLabel = relname.String
+		}
+		// The index is not known until CREATE INDEX has created its entry.
+		indexrelnameLabel := "unknown"
+		if indexrelname.Valid {
+			indexrelnameLabel = indexrelname.String
+		}
+		labels := []string{datnameLabel, relnameLabel, indexrelnameLabel}
+
+		ch <- prometheus.MustNewConstMetric(
+			statProgressCreateIndexPhase,
+			prometheus.GaugeValue, 1,
+			datnameLabel, relnameLabel, indexrelnameLabel, command.String, phase.String,
+		)
+		for _, progress := range []struct {
+			desc  *prometheus.Desc
+			value sql.NullInt64
+		}{
+			{statProgressCreateIndexLockersTotal, lockersTotal},
+			{statProgressCreateIndexLockersDone, lockersDone},
+			{statProgressCreateIndexBlocksTotal, blocksTotal},
+			{statProgressCreateIndexBlocksDone, blocksDone},
+			{statProgressCreateIndexTuplesTotal, tuplesTotal},
+			{statProgressCreateIndexTuplesDone, tuplesDone},
+		} {
+			ch <- prometheus.MustNewConstMetric(progress.desc, prometheus.GaugeValue, float64(progress.value.Int64), labels...)
+		}
+	}
+	return rows.Err()
+}
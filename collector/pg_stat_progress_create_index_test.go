@@ -0,0 +1,73 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package collector
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/blang/semver/v4"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+)
+
+func TestPGStatProgressCreateIndexCollector(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error opening a stub db connection: %s", err)
+	}
+	defer db.Close()
+
+	inst := &Instance{db: db, version: semver.MustParse("16.0.0")}
+
+	columns := []string{"datname", "relname", "indexrelname", "command", "phase",
+		"lockers_total", "lockers_done", "blocks_total", "blocks_done", "tuples_total", "tuples_done"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("postgres", "orders", "orders_created_at_idx", "CREATE INDEX CONCURRENTLY", "building index: scanning table",
+			0, 0, 250000, 61000, 0, 0)
+	mock.ExpectQuery(sanitizeQuery(statProgressCreateIndexQuery)).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		defer close(ch)
+		c := PGStatProgressCreateIndexCollector{}
+
+		if err := c.Update(context.Background(), inst, ch); err != nil {
+			t.Errorf("Error calling PGStatProgressCreateIndexCollector.Update: %s", err)
+		}
+	}()
+
+	labels := labelMap{"datname": "postgres", "relname": "orders", "indexrelname": "orders_created_at_idx"}
+	expected := []MetricResult{
+		{labels: labelMap{"datname": "postgres", "relname": "orders", "indexrelname": "orders_created_at_idx", "command": "CREATE INDEX CONCURRENTLY", "phase": "building index: scanning table"}, value: 1, metricType: dto.MetricType_GAUGE},
+		{labels: labels, value: 0, metricType: dto.MetricType_GAUGE},
+		{labels: labels, value: 0, metricType: dto.MetricType_GAUGE},
+		{labels: labels, value: 250000, metricType: dto.MetricType_GAUGE},
+		{labels: labels, value: 61000, metricType: dto.MetricType_GAUGE},
+		{labels: labels, value: 0, metricType: dto.MetricType_GAUGE},
+		{labels: labels, value: 0, metricType: dto.MetricType_GAUGE},
+	}
+
+	convey.Convey("Metrics comparison", t, func() {
+		var metrics []MetricResult
+		for m := range ch {
+			metrics = append(metrics, readMetric(m))
+		}
+		convey.So(metrics, convey.ShouldResemble, expected)
+	})
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
@@ -116,8 +116,7 @@ var (
 
 func (c *PGStatProgressVacuumCollector) Update(ctx context.Context, instance *Instance, ch chan<- prometheus.Metric) error {
 	db := instance.getDB()
-	rows, err := db.QueryContext(ctx,
-		statProgressVacuumQuery)
+	rows, err := queryWithOverride(ctx, db, progressVacuumSubsystem, statProgressVacuumQuery, 9)
 
 	if err != nil {
 		return err
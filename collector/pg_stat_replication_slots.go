@@ -0,0 +1,103 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+
+	"github.com/blang/semver/v4"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const statReplicationSlotsSubsystem = "stat_replication_slots"
+
+func init() {
+	registerCollector(statReplicationSlotsSubsystem, defaultDisabled, NewPGStatReplicationSlotsCollector)
+}
+
+// PGStatReplicationSlotsCollector reports how often logical decoding on each
+// slot spilled to disk or streamed in-progress transactions, both signs that
+// logical_decoding_work_mem is too small for the workload.
+type PGStatReplicationSlotsCollector struct {
+	log *slog.Logger
+}
+
+func NewPGStatReplicationSlotsCollector(config collectorConfig) (Collector, error) {
+	return &PGStatReplicationSlotsCollector{log: config.logger}, nil
+}
+
+var (
+	pgReplicationSlotSpillTxnsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, replicationSlotSubsystem, "spill_txns_total"),
+		"Number of transactions spilled to disk once their decoding exceeded logical_decoding_work_mem",
+		[]string{"slot_name"}, nil,
+	)
+	pgReplicationSlotSpillBytesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, replicationSlotSubsystem, "spill_bytes_total"),
+		"Bytes of decoded transaction data spilled to disk",
+		[]string{"slot_name"}, nil,
+	)
+	pgReplicationSlotStreamTxnsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, replicationSlotSubsystem, "stream_txns_total"),
+		"Number of in-progress transactions streamed to the decoding output plugin",
+		[]string{"slot_name"}, nil,
+	)
+
+	pgStatReplicationSlotsQuery = `SELECT
+		slot_name,
+		spill_txns,
+		spill_bytes,
+		stream_txns
+	FROM pg_catalog.pg_stat_replication_slots`
+)
+
+func (c PGStatReplicationSlotsCollector) Update(ctx context.Context, instance *Instance, ch chan<- prometheus.Metric) error {
+	if instance.version.LT(semver.MustParse("14.0.0")) {
+		return skipUnsupportedVersion(c.log, "pg_stat_replication_slots is not available before PostgreSQL 14")
+	}
+
+	db := instance.getDB()
+	rows, err := queryWithOverride(ctx, db, statReplicationSlotsSubsystem, pgStatReplicationSlotsQuery, 4)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var slotName sql.NullString
+		var spillTxns, spillBytes, streamTxns sql.NullInt64
+		if err := rows.Scan(&slotName, &spillTxns, &spillBytes, &streamTxns); err != nil {
+			return err
+		}
+		if !slotName.Valid {
+			continue
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			pgReplicationSlotSpillTxnsDesc,
+			prometheus.CounterValue, float64(spillTxns.Int64), slotName.String,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			pgReplicationSlotSpillBytesDesc,
+			prometheus.CounterValue, float64(spillBytes.Int64), slotName.String,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			pgReplicationSlotStreamTxnsDesc,
+			prometheus.CounterValue, float64(streamTxns.Int64), slotName.String,
+		)
+	}
+	return rows.Err()
+}
@@ -0,0 +1,118 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+
+	"github.com/blang/semver/v4"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const statSLRUSubsystem = "stat_slru"
+
+func init() {
+	registerCollector(statSLRUSubsystem, defaultDisabled, NewPGStatSLRUCollector)
+}
+
+// PGStatSLRUCollector reports access counters for each simple LRU cache,
+// such as the multixact and subtransaction caches, whose thrashing shows up
+// as contention that is otherwise hard to attribute.
+type PGStatSLRUCollector struct {
+	log *slog.Logger
+}
+
+func NewPGStatSLRUCollector(config collectorConfig) (Collector, error) {
+	return &PGStatSLRUCollector{log: config.logger}, nil
+}
+
+var (
+	statSLRUBlksHitDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, statSLRUSubsystem, "blks_hit_total"),
+		"Number of times disk blocks were found already in the SLRU",
+		[]string{"name"}, nil,
+	)
+	statSLRUBlksReadDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, statSLRUSubsystem, "blks_read_total"),
+		"Number of disk blocks read for the SLRU",
+		[]string{"name"}, nil,
+	)
+	statSLRUBlksWrittenDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, statSLRUSubsystem, "blks_written_total"),
+		"Number of disk blocks written for the SLRU",
+		[]string{"name"}, nil,
+	)
+	statSLRUFlushesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, statSLRUSubsystem, "flushes_total"),
+		"Number of flushes of dirty data for the SLRU",
+		[]string{"name"}, nil,
+	)
+	statSLRUTruncatesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, statSLRUSubsystem, "truncates_total"),
+		"Number of truncates for the SLRU",
+		[]string{"name"}, nil,
+	)
+
+	statSLRUQuery = `SELECT
+		name,
+		blks_hit,
+		blks_read,
+		blks_written,
+		flushes,
+		truncates
+	FROM pg_catalog.pg_stat_slru`
+)
+
+func (c PGStatSLRUCollector) Update(ctx context.Context, instance *Instance, ch chan<- prometheus.Metric) error {
+	if instance.version.LT(semver.MustParse("13.0.0")) {
+		return skipUnsupportedVersion(c.log, "pg_stat_slru collector is not available on PostgreSQL < 13, skipping")
+	}
+
+	db := instance.getDB()
+	rows, err := queryWithOverride(ctx, db, statSLRUSubsystem, statSLRUQuery, 6)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var name sql.NullString
+		var blksHit, blksRead, blksWritten, flushes, truncates sql.NullInt64
+		if err := rows.Scan(&name, &blksHit, &blksRead, &blksWritten, &flushes, &truncates); err != nil {
+			return err
+		}
+		if !name.Valid {
+			continue
+		}
+
+		for _, counter := range []struct {
+			desc  *prometheus.Desc
+			value sql.NullInt64
+		}{
+			{statSLRUBlksHitDesc, blksHit},
+			{statSLRUBlksReadDesc, blksRead},
+			{statSLRUBlksWrittenDesc, blksWritten},
+			{statSLRUFlushesDesc, flushes},
+			{statSLRUTruncatesDesc, truncates},
+		} {
+			ch <- prometheus.MustNewConstMetric(
+				counter.desc,
+				prometheus.CounterValue, float64(counter.value.Int64), name.String,
+			)
+		}
+	}
+	return rows.Err()
+}
@@ -0,0 +1,67 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package collector
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/blang/semver/v4"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+)
+
+func TestPGStatSLRUCollector(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error opening a stub db connection: %s", err)
+	}
+	defer db.Close()
+
+	inst := &Instance{db: db, version: semver.MustParse("15.0.0")}
+
+	columns := []string{"name", "blks_hit", "blks_read", "blks_written", "flushes", "truncates"}
+	rows := sqlmock.NewRows(columns).
+		AddRow("Subtrans", 982331, 4410, 3920, 17, 5)
+	mock.ExpectQuery(sanitizeQuery(statSLRUQuery)).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		defer close(ch)
+		c := PGStatSLRUCollector{}
+
+		if err := c.Update(context.Background(), inst, ch); err != nil {
+			t.Errorf("Error calling PGStatSLRUCollector.Update: %s", err)
+		}
+	}()
+
+	expected := []MetricResult{
+		{labels: labelMap{"name": "Subtrans"}, value: 982331, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"name": "Subtrans"}, value: 4410, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"name": "Subtrans"}, value: 3920, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"name": "Subtrans"}, value: 17, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"name": "Subtrans"}, value: 5, metricType: dto.MetricType_COUNTER},
+	}
+
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range expected {
+			m := readMetric(<-ch)
+			convey.So(expect, convey.ShouldResemble, m)
+		}
+	})
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
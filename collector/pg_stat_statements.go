@@ -29,6 +29,7 @@ const statStatementsSubsystem = "stat_statements"
 var (
 	includeQueryFlag    *bool = nil
 	statementLengthFlag *uint = nil
+	statementsTopNFlag  *uint = nil
 )
 
 func init() {
@@ -47,12 +48,18 @@ func init() {
 		"Maximum length of the statement text.").
 		Default("120").
 		Uint()
+	statementsTopNFlag = kingpin.Flag(
+		fmt.Sprint(collectorFlagPrefix, statStatementsSubsystem, ".top-n"),
+		"Maximum number of statements to report, by total execution time.").
+		Default("100").
+		Uint()
 }
 
 type PGStatStatementsCollector struct {
 	log                   *slog.Logger
 	includeQueryStatement bool
 	statementLength       uint
+	topN                  uint
 }
 
 func NewPGStatStatementsCollector(config collectorConfig) (Collector, error) {
@@ -60,6 +67,7 @@ func NewPGStatStatementsCollector(config collectorConfig) (Collector, error) {
 		log:                   config.logger,
 		includeQueryStatement: *includeQueryFlag,
 		statementLength:       *statementLengthFlag,
+		topN:                  *statementsTopNFlag,
 	}, nil
 }
 
@@ -94,6 +102,24 @@ var (
 		[]string{"user", "datname", "queryid"},
 		prometheus.Labels{},
 	)
+	statStatementsMeanSeconds = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, statStatementsSubsystem, "mean_seconds"),
+		"Mean time spent in the statement, in seconds",
+		[]string{"user", "datname", "queryid"},
+		prometheus.Labels{},
+	)
+	statStatementsSharedBlocksHitTotal = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, statStatementsSubsystem, "shared_blocks_hit_total"),
+		"Total number of shared block cache hits by the statement",
+		[]string{"user", "datname", "queryid"},
+		prometheus.Labels{},
+	)
+	statStatementsSharedBlocksReadTotal = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, statStatementsSubsystem, "shared_blocks_read_total"),
+		"Total number of shared blocks read by the statement",
+		[]string{"user", "datname", "queryid"},
+		prometheus.Labels{},
+	)
 
 	statStatementsQuery = prometheus.NewDesc(
 		prometheus.BuildFQName(namespace, statStatementsSubsystem, "query_id"),
@@ -115,7 +141,10 @@ const (
 		pg_stat_statements.total_time / 1000.0 as seconds_total,
 		pg_stat_statements.rows as rows_total,
 		pg_stat_statements.blk_read_time / 1000.0 as block_read_seconds_total,
-		pg_stat_statements.blk_write_time / 1000.0 as block_write_seconds_total
+		pg_stat_statements.blk_write_time / 1000.0 as block_write_seconds_total,
+		pg_stat_statements.mean_time / 1000.0 as mean_seconds,
+		pg_stat_statements.shared_blks_hit as shared_blocks_hit_total,
+		pg_stat_statements.shared_blks_read as shared_blocks_read_total
 		FROM pg_stat_statements
 	JOIN pg_database
 		ON pg_database.oid = pg_stat_statements.dbid
@@ -126,7 +155,7 @@ const (
 			FROM pg_stat_statements
 		)
 	ORDER BY seconds_total DESC
-	LIMIT 100;`
+	LIMIT $1;`
 
 	pgStatStatementsNewQuery = `SELECT
 		pg_get_userbyid(userid) as user,
@@ -137,7 +166,10 @@ const (
 		pg_stat_statements.total_exec_time / 1000.0 as seconds_total,
 		pg_stat_statements.rows as rows_total,
 		pg_stat_statements.blk_read_time / 1000.0 as block_read_seconds_total,
-		pg_stat_statements.blk_write_time / 1000.0 as block_write_seconds_total
+		pg_stat_statements.blk_write_time / 1000.0 as block_write_seconds_total,
+		pg_stat_statements.mean_exec_time / 1000.0 as mean_seconds,
+		pg_stat_statements.shared_blks_hit as shared_blocks_hit_total,
+		pg_stat_statements.shared_blks_read as shared_blocks_read_total
 		FROM pg_stat_statements
 	JOIN pg_database
 		ON pg_database.oid = pg_stat_statements.dbid
@@ -148,7 +180,7 @@ const (
 			FROM pg_stat_statements
 		)
 	ORDER BY seconds_total DESC
-	LIMIT 100;`
+	LIMIT $1;`
 
 	pgStatStatementsQuery_PG17 = `SELECT
 		pg_get_userbyid(userid) as user,
@@ -159,7 +191,10 @@ const (
 		pg_stat_statements.total_exec_time / 1000.0 as seconds_total,
 		pg_stat_statements.rows as rows_total,
 		pg_stat_statements.shared_blk_read_time / 1000.0 as block_read_seconds_total,
-		pg_stat_statements.shared_blk_write_time / 1000.0 as block_write_seconds_total
+		pg_stat_statements.shared_blk_write_time / 1000.0 as block_write_seconds_total,
+		pg_stat_statements.mean_exec_time / 1000.0 as mean_seconds,
+		pg_stat_statements.shared_blks_hit as shared_blocks_hit_total,
+		pg_stat_statements.shared_blks_read as shared_blocks_read_total
 		FROM pg_stat_statements
 	JOIN pg_database
 		ON pg_database.oid = pg_stat_statements.dbid
@@ -170,7 +205,7 @@ const (
 			FROM pg_stat_statements
 		)
 	ORDER BY seconds_total DESC
-	LIMIT 100;`
+	LIMIT $1;`
 )
 
 func (c PGStatStatementsCollector) Update(ctx context.Context, instance *Instance, ch chan<- prometheus.Metric) error {
@@ -187,10 +222,14 @@ func (c PGStatStatementsCollector) Update(ctx context.Context, instance *Instanc
 	if c.includeQueryStatement {
 		querySelect = fmt.Sprintf(pgStatStatementQuerySelect, c.statementLength)
 	}
-	query := fmt.Sprintf(queryTemplate, querySelect)
+	query := terminateQuery(fmt.Sprintf(queryTemplate, querySelect))
+	columns := 11
+	if c.includeQueryStatement {
+		columns++
+	}
 
 	db := instance.getDB()
-	rows, err := db.QueryContext(ctx, query)
+	rows, err := queryWithOverride(ctx, db, statStatementsSubsystem, query, columns, c.topN)
 
 	var presentQueryIds = make(map[string]struct{})
 
@@ -200,13 +239,13 @@ func (c PGStatStatementsCollector) Update(ctx context.Context, instance *Instanc
 	defer rows.Close()
 	for rows.Next() {
 		var user, datname, queryid, statement sql.NullString
-		var callsTotal, rowsTotal sql.NullInt64
-		var secondsTotal, blockReadSecondsTotal, blockWriteSecondsTotal sql.NullFloat64
+		var callsTotal, rowsTotal, sharedBlocksHitTotal, sharedBlocksReadTotal sql.NullInt64
+		var secondsTotal, blockReadSecondsTotal, blockWriteSecondsTotal, meanSeconds sql.NullFloat64
 		var columns []any
 		if c.includeQueryStatement {
-			columns = []any{&user, &datname, &queryid, &statement, &callsTotal, &secondsTotal, &rowsTotal, &blockReadSecondsTotal, &blockWriteSecondsTotal}
+			columns = []any{&user, &datname, &queryid, &statement, &callsTotal, &secondsTotal, &rowsTotal, &blockReadSecondsTotal, &blockWriteSecondsTotal, &meanSeconds, &sharedBlocksHitTotal, &sharedBlocksReadTotal}
 		} else {
-			columns = []any{&user, &datname, &queryid, &callsTotal, &secondsTotal, &rowsTotal, &blockReadSecondsTotal, &blockWriteSecondsTotal}
+			columns = []any{&user, &datname, &queryid, &callsTotal, &secondsTotal, &rowsTotal, &blockReadSecondsTotal, &blockWriteSecondsTotal, &meanSeconds, &sharedBlocksHitTotal, &sharedBlocksReadTotal}
 		}
 		if err := rows.Scan(columns...); err != nil {
 			return err
@@ -280,6 +319,39 @@ func (c PGStatStatementsCollector) Update(ctx context.Context, instance *Instanc
 			userLabel, datnameLabel, queryidLabel,
 		)
 
+		meanSecondsMetric := 0.0
+		if meanSeconds.Valid {
+			meanSecondsMetric = meanSeconds.Float64
+		}
+		ch <- prometheus.MustNewConstMetric(
+			statStatementsMeanSeconds,
+			prometheus.GaugeValue,
+			meanSecondsMetric,
+			userLabel, datnameLabel, queryidLabel,
+		)
+
+		sharedBlocksHitTotalMetric := 0.0
+		if sharedBlocksHitTotal.Valid {
+			sharedBlocksHitTotalMetric = float64(sharedBlocksHitTotal.Int64)
+		}
+		ch <- prometheus.MustNewConstMetric(
+			statStatementsSharedBlocksHitTotal,
+			prometheus.CounterValue,
+			sharedBlocksHitTotalMetric,
+			userLabel, datnameLabel, queryidLabel,
+		)
+
+		sharedBlocksReadTotalMetric := 0.0
+		if sharedBlocksReadTotal.Valid {
+			sharedBlocksReadTotalMetric = float64(sharedBlocksReadTotal.Int64)
+		}
+		ch <- prometheus.MustNewConstMetric(
+			statStatementsSharedBlocksReadTotal,
+			prometheus.CounterValue,
+			sharedBlocksReadTotalMetric,
+			userLabel, datnameLabel, queryidLabel,
+		)
+
 		if c.includeQueryStatement {
 			_, ok := presentQueryIds[queryidLabel]
 			if !ok {
@@ -0,0 +1,178 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+
+	"github.com/blang/semver/v4"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const statSubscriptionSubsystem = "stat_subscription"
+
+func init() {
+	registerCollector(statSubscriptionSubsystem, defaultDisabled, NewPGStatSubscriptionCollector)
+}
+
+// PGStatSubscriptionCollector reports the health of logical replication
+// subscriptions: whether the apply worker is running, how long ago it last
+// heard from the publisher, and, from PostgreSQL 15, how many apply and
+// initial sync errors it has hit. A subscription that keeps failing
+// otherwise just falls further behind without any other signal.
+type PGStatSubscriptionCollector struct {
+	log *slog.Logger
+}
+
+func NewPGStatSubscriptionCollector(config collectorConfig) (Collector, error) {
+	return &PGStatSubscriptionCollector{log: config.logger}, nil
+}
+
+var (
+	statSubscriptionWorkerRunningDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, statSubscriptionSubsystem, "worker_running"),
+		"Whether the subscription's apply worker is running",
+		[]string{"subname"}, nil,
+	)
+	statSubscriptionLastMsgReceiptAgeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, statSubscriptionSubsystem, "last_msg_receipt_age_seconds"),
+		"Seconds since the apply worker last received a message from the publisher",
+		[]string{"subname"}, nil,
+	)
+	statSubscriptionLastMsgLagDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, statSubscriptionSubsystem, "last_msg_lag_seconds"),
+		"Seconds between the publisher sending the last message and the apply worker receiving it",
+		[]string{"subname"}, nil,
+	)
+	statSubscriptionApplyErrorsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, statSubscriptionSubsystem, "apply_errors_total"),
+		"Number of errors that occurred while applying changes",
+		[]string{"subname"}, nil,
+	)
+	statSubscriptionSyncErrorsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, statSubscriptionSubsystem, "sync_errors_total"),
+		"Number of errors that occurred during the initial table synchronization",
+		[]string{"subname"}, nil,
+	)
+
+	// pg_stat_subscription has a row per worker, including table
+	// synchronization workers, and a row without a worker for subscriptions
+	// whose apply worker is not running. Only the apply worker, which has
+	// no relid, is considered.
+	statSubscriptionQuery = `SELECT
+		subname,
+		bool_or(pid IS NOT NULL) AS worker_running,
+		EXTRACT(EPOCH FROM (now() - max(last_msg_receipt_time))) AS last_msg_receipt_age_seconds,
+		EXTRACT(EPOCH FROM max(last_msg_receipt_time - last_msg_send_time)) AS last_msg_lag_seconds
+	FROM pg_catalog.pg_stat_subscription
+	WHERE relid IS NULL
+	GROUP BY subname
+	ORDER BY subname`
+
+	statSubscriptionStatsQuery = `SELECT
+		subname,
+		apply_error_count,
+		sync_error_count
+	FROM pg_catalog.pg_stat_subscription_stats
+	ORDER BY subname`
+)
+
+func (c PGStatSubscriptionCollector) Update(ctx context.Context, instance *Instance, ch chan<- prometheus.Metric) error {
+	if instance.version.LT(semver.MustParse("10.0.0")) {
+		return skipUnsupportedVersion(c.log, "logical replication subscriptions are not available before PostgreSQL 10")
+	}
+
+	db := instance.getDB()
+	if err := c.updateWorkers(ctx, db, ch); err != nil {
+		return err
+	}
+	if instance.version.LT(semver.MustParse("15.0.0")) {
+		return nil
+	}
+	return c.updateStats(ctx, db, ch)
+}
+
+func (c PGStatSubscriptionCollector) updateWorkers(ctx context.Context, db *sql.DB, ch chan<- prometheus.Metric) error {
+	rows, err := queryWithOverride(ctx, db, statSubscriptionSubsystem, statSubscriptionQuery, 4)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var subname sql.NullString
+		var running sql.NullBool
+		var receiptAge, lag sql.NullFloat64
+		if err := rows.Scan(&subname, &running, &receiptAge, &lag); err != nil {
+			return err
+		}
+		if !subname.Valid {
+			continue
+		}
+
+		runningMetric := 0.0
+		if running.Bool {
+			runningMetric = 1
+		}
+		ch <- prometheus.MustNewConstMetric(
+			statSubscriptionWorkerRunningDesc,
+			prometheus.GaugeValue, runningMetric, subname.String,
+		)
+		// Both timings are NULL until the worker has received a message.
+		if receiptAge.Valid {
+			ch <- prometheus.MustNewConstMetric(
+				statSubscriptionLastMsgReceiptAgeDesc,
+				prometheus.GaugeValue, receiptAge.Float64, subname.String,
+			)
+		}
+		if lag.Valid {
+			ch <- prometheus.MustNewConstMetric(
+				statSubscriptionLastMsgLagDesc,
+				prometheus.GaugeValue, lag.Float64, subname.String,
+			)
+		}
+	}
+	return rows.Err()
+}
+
+func (c PGStatSubscriptionCollector) updateStats(ctx context.Context, db *sql.DB, ch chan<- prometheus.Metric) error {
+	rows, err := db.QueryContext(ctx, statSubscriptionStatsQuery)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var subname sql.NullString
+		var applyErrors, syncErrors sql.NullInt64
+		if err := rows.Scan(&subname, &applyErrors, &syncErrors); err != nil {
+			return err
+		}
+		if !subname.Valid {
+			continue
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			statSubscriptionApplyErrorsDesc,
+			prometheus.CounterValue, float64(applyErrors.Int64), subname.String,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			statSubscriptionSyncErrorsDesc,
+			prometheus.CounterValue, float64(syncErrors.Int64), subname.String,
+		)
+	}
+	return rows.Err()
+}
@@ -0,0 +1,121 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package collector
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/blang/semver/v4"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+)
+
+func TestPGStatSubscriptionCollector(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error opening a stub db connection: %s", err)
+	}
+	defer db.Close()
+
+	inst := &Instance{db: db, version: semver.MustParse("16.0.0")}
+
+	workerColumns := []string{"subname", "worker_running", "last_msg_receipt_age_seconds", "last_msg_lag_seconds"}
+	workerRows := sqlmock.NewRows(workerColumns).
+		AddRow("orders_sub", true, 2.5, 0.25).
+		AddRow("stalled_sub", false, nil, nil)
+	mock.ExpectQuery(sanitizeQuery(statSubscriptionQuery)).WillReturnRows(workerRows)
+
+	statsColumns := []string{"subname", "apply_error_count", "sync_error_count"}
+	statsRows := sqlmock.NewRows(statsColumns).
+		AddRow("orders_sub", 0, 0).
+		AddRow("stalled_sub", 42, 1)
+	mock.ExpectQuery(sanitizeQuery(statSubscriptionStatsQuery)).WillReturnRows(statsRows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		defer close(ch)
+		c := PGStatSubscriptionCollector{}
+
+		if err := c.Update(context.Background(), inst, ch); err != nil {
+			t.Errorf("Error calling PGStatSubscriptionCollector.Update: %s", err)
+		}
+	}()
+
+	expected := []MetricResult{
+		{labels: labelMap{"subname": "orders_sub"}, value: 1, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"subname": "orders_sub"}, value: 2.5, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"subname": "orders_sub"}, value: 0.25, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"subname": "stalled_sub"}, value: 0, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"subname": "orders_sub"}, value: 0, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"subname": "orders_sub"}, value: 0, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"subname": "stalled_sub"}, value: 42, metricType: dto.MetricType_COUNTER},
+		{labels: labelMap{"subname": "stalled_sub"}, value: 1, metricType: dto.MetricType_COUNTER},
+	}
+
+	convey.Convey("Metrics comparison", t, func() {
+		var metrics []MetricResult
+		for m := range ch {
+			metrics = append(metrics, readMetric(m))
+		}
+		convey.So(metrics, convey.ShouldResemble, expected)
+	})
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
+
+func TestPGStatSubscriptionCollectorBeforePG15(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error opening a stub db connection: %s", err)
+	}
+	defer db.Close()
+
+	inst := &Instance{db: db, version: semver.MustParse("14.0.0")}
+
+	// pg_stat_subscription_stats does not exist yet, so it is not queried.
+	workerColumns := []string{"subname", "worker_running", "last_msg_receipt_age_seconds", "last_msg_lag_seconds"}
+	workerRows := sqlmock.NewRows(workerColumns).
+		AddRow("orders_sub", true, 2.5, 0.25)
+	mock.ExpectQuery(sanitizeQuery(statSubscriptionQuery)).WillReturnRows(workerRows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		defer close(ch)
+		c := PGStatSubscriptionCollector{}
+
+		if err := c.Update(context.Background(), inst, ch); err != nil {
+			t.Errorf("Error calling PGStatSubscriptionCollector.Update: %s", err)
+		}
+	}()
+
+	expected := []MetricResult{
+		{labels: labelMap{"subname": "orders_sub"}, value: 1, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"subname": "orders_sub"}, value: 2.5, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"subname": "orders_sub"}, value: 0.25, metricType: dto.MetricType_GAUGE},
+	}
+
+	convey.Convey("Metrics comparison", t, func() {
+		var metrics []MetricResult
+		for m := range ch {
+			metrics = append(metrics, readMetric(m))
+		}
+		convey.So(metrics, convey.ShouldResemble, expected)
+	})
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
@@ -194,8 +194,7 @@ var (
 
 func (c *PGStatUserTablesCollector) Update(ctx context.Context, instance *Instance, ch chan<- prometheus.Metric) error {
 	db := instance.getDB()
-	rows, err := db.QueryContext(ctx,
-		statUserTablesQuery)
+	rows, err := queryWithOverride(ctx, db, userTableSubsystem, statUserTablesQuery, 24)
 
 	if err != nil {
 		return err
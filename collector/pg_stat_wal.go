@@ -100,7 +100,7 @@ var statsWALStatsResetDesc = prometheus.NewDesc(
 )
 
 func statWALQuery(columns []string) string {
-	return fmt.Sprintf("SELECT %s FROM pg_stat_wal;", strings.Join(columns, ","))
+	return terminateQuery(fmt.Sprintf("SELECT %s FROM pg_stat_wal", strings.Join(columns, ",")))
 }
 
 func (c *PGStatWALCollector) Update(ctx context.Context, instance *Instance, ch chan<- prometheus.Metric) error {
@@ -118,9 +118,7 @@ func (c *PGStatWALCollector) Update(ctx context.Context, instance *Instance, ch
 		"stats_reset",      // timestamp with time zone
 	}
 
-	rows, err := db.QueryContext(ctx,
-		statWALQuery(columns),
-	)
+	rows, err := queryWithOverride(ctx, db, statWALSubsystem, statWALQuery(columns), len(columns))
 	if err != nil {
 		return err
 	}
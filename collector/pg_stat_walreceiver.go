@@ -128,15 +128,18 @@ func (c *PGStatWalReceiverCollector) Update(ctx context.Context, instance *Insta
 
 	hasFlushedLSN := hasFlushedLSNRows.Next()
 	var query string
+	columns := 11
 	if hasFlushedLSN {
 		query = fmt.Sprintf(pgStatWalReceiverQueryTemplate, "(flushed_lsn - '0/0') % (2^52)::bigint as flushed_lsn,\n")
+		columns++
 	} else {
 		query = fmt.Sprintf(pgStatWalReceiverQueryTemplate, "")
 	}
+	query = terminateQuery(query)
 
 	hasFlushedLSNRows.Close()
 
-	rows, err := db.QueryContext(ctx, query)
+	rows, err := queryWithOverride(ctx, db, statWalReceiverSubsystem, query, columns)
 	if err != nil {
 		return err
 	}
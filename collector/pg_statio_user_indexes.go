@@ -61,8 +61,7 @@ var (
 
 func (c *PGStatioUserIndexesCollector) Update(ctx context.Context, instance *Instance, ch chan<- prometheus.Metric) error {
 	db := instance.getDB()
-	rows, err := db.QueryContext(ctx,
-		statioUserIndexesQuery)
+	rows, err := queryWithOverride(ctx, db, statioUserIndexesSubsystem, statioUserIndexesQuery, 5)
 
 	if err != nil {
 		return err
@@ -102,8 +102,7 @@ var (
 
 func (PGStatIOUserTablesCollector) Update(ctx context.Context, instance *Instance, ch chan<- prometheus.Metric) error {
 	db := instance.getDB()
-	rows, err := db.QueryContext(ctx,
-		statioUserTablesQuery)
+	rows, err := queryWithOverride(ctx, db, statioUserTableSubsystem, statioUserTablesQuery, 11)
 
 	if err != nil {
 		return err
@@ -61,14 +61,13 @@ WHERE trim(configured.slot_name) != ''
 
 func (c *PGSynchronizedStandbySlotsCollector) Update(ctx context.Context, instance *Instance, ch chan<- prometheus.Metric) error {
 	if instance.version.LT(semver.MustParse("17.0.0")) {
-		c.log.Debug("synchronized_standby_slots collector is not available on PostgreSQL < 17, skipping")
-		return nil
+		return skipUnsupportedVersion(c.log, "synchronized_standby_slots collector is not available on PostgreSQL < 17, skipping")
 	}
 
 	db := instance.getDB()
 
 	var invalidCount sql.NullInt64
-	if err := db.QueryRowContext(ctx, synchronizedStandbySlotsQuery).Scan(&invalidCount); err != nil {
+	if err := scanRowWithOverride(ctx, db, synchronizedStandbySlotsSubsystem, synchronizedStandbySlotsQuery, nil, &invalidCount); err != nil {
 		return err
 	}
 
@@ -15,6 +15,7 @@ package collector
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
@@ -53,6 +54,49 @@ func TestPGSynchronizedStandbySlotsBeforePG17(t *testing.T) {
 	}
 }
 
+func TestPGSynchronizedStandbySlotsStrictVersionGating(t *testing.T) {
+	tests := []struct {
+		name    string
+		strict  bool
+		wantErr error
+	}{
+		{name: "lenient", strict: false, wantErr: nil},
+		{name: "strict", strict: true, wantErr: ErrUnsupportedVersion},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func(v bool) { *strictVersionGating = v }(*strictVersionGating)
+			*strictVersionGating = tt.strict
+
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("Error opening a stub database connection: %s", err)
+			}
+			defer db.Close()
+
+			inst := &Instance{db: db, version: semver.MustParse("16.4.0")}
+
+			ch := make(chan prometheus.Metric)
+			errCh := make(chan error, 1)
+			go func() {
+				defer close(ch)
+				errCh <- newTestSyncStandbySlotsCollector().Update(context.Background(), inst, ch)
+			}()
+
+			for range ch {
+				t.Error("Expected no metrics for PG < 17, but got one")
+			}
+			if err := <-errCh; !errors.Is(err, tt.wantErr) {
+				t.Errorf("Expected error %v, got: %v", tt.wantErr, err)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled exceptions: %s", err)
+			}
+		})
+	}
+}
+
 func TestPGSynchronizedStandbySlotsAllValid(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	if err != nil {
@@ -0,0 +1,103 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const tableCacheHitSubsystem = "table_cache_hit"
+
+var tableCacheHitTopNFlag *uint = nil
+
+func init() {
+	registerCollector(tableCacheHitSubsystem, defaultDisabled, NewPGTableCacheHitCollector)
+
+	tableCacheHitTopNFlag = kingpin.Flag(
+		fmt.Sprint(collectorFlagPrefix, tableCacheHitSubsystem, ".top-n"),
+		"Number of most-accessed tables to report the cache hit ratio for.").
+		Default("20").
+		Uint()
+}
+
+// PGTableCacheHitCollector reports the shared buffer hit ratio of the most
+// accessed user tables, so hot tables with poor locality stand out from the
+// database-wide ratio.
+type PGTableCacheHitCollector struct {
+	log  *slog.Logger
+	topN uint
+}
+
+func NewPGTableCacheHitCollector(config collectorConfig) (Collector, error) {
+	return &PGTableCacheHitCollector{
+		log:  config.logger,
+		topN: *tableCacheHitTopNFlag,
+	}, nil
+}
+
+var (
+	pgTableCacheHitRatioDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "table", "cache_hit_ratio"),
+		"Ratio of heap block reads served from shared buffers for the table",
+		[]string{"datname", "schemaname", "relname"}, nil,
+	)
+
+	pgTableCacheHitQuery = `SELECT
+		current_database() AS datname,
+		schemaname,
+		relname,
+		heap_blks_hit,
+		heap_blks_read
+	FROM pg_catalog.pg_statio_user_tables
+	ORDER BY COALESCE(heap_blks_hit, 0) + COALESCE(heap_blks_read, 0) DESC
+	LIMIT $1`
+)
+
+func (c PGTableCacheHitCollector) Update(ctx context.Context, instance *Instance, ch chan<- prometheus.Metric) error {
+	db := instance.getDB()
+	rows, err := queryWithOverride(ctx, db, tableCacheHitSubsystem, pgTableCacheHitQuery, 5, c.topN)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var datname, schemaname, relname sql.NullString
+		var hit, read sql.NullInt64
+		if err := rows.Scan(&datname, &schemaname, &relname, &hit, &read); err != nil {
+			return err
+		}
+
+		if !datname.Valid || !schemaname.Valid || !relname.Valid {
+			continue
+		}
+		// A table that has never been read has no meaningful ratio.
+		total := hit.Int64 + read.Int64
+		if total == 0 {
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(
+			pgTableCacheHitRatioDesc,
+			prometheus.GaugeValue, float64(hit.Int64)/float64(total),
+			datname.String, schemaname.String, relname.String,
+		)
+	}
+	return rows.Err()
+}
@@ -0,0 +1,102 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const tablespacesSubsystem = "tablespaces"
+
+func init() {
+	registerCollector(tablespacesSubsystem, defaultDisabled, NewPGTablespacesCollector)
+}
+
+type PGTablespacesCollector struct {
+	log *slog.Logger
+}
+
+func NewPGTablespacesCollector(config collectorConfig) (Collector, error) {
+	return &PGTablespacesCollector{log: config.logger}, nil
+}
+
+var (
+	pgTablespaceSizeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "tablespace", "size_bytes"),
+		"Disk space used by the tablespace",
+		[]string{"spcname"}, nil,
+	)
+
+	pgTablespacesQuery    = "SELECT oid, spcname FROM pg_catalog.pg_tablespace"
+	pgTablespaceSizeQuery = "SELECT pg_tablespace_size($1::oid)"
+)
+
+// Update implements Collector and exposes tablespace sizes. Sizes are queried
+// one tablespace at a time so that a tablespace the exporter role may not
+// read (pg_tablespace_size needs CREATE on it or pg_read_all_stats) is
+// skipped instead of failing the whole collector.
+func (c PGTablespacesCollector) Update(ctx context.Context, instance *Instance, ch chan<- prometheus.Metric) error {
+	db := instance.getDB()
+	rows, err := queryWithOverride(ctx, db, tablespacesSubsystem, pgTablespacesQuery, 2)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	type tablespace struct {
+		oid  int64
+		name string
+	}
+	var tablespaces []tablespace
+	for rows.Next() {
+		var oid sql.NullInt64
+		var spcname sql.NullString
+		if err := rows.Scan(&oid, &spcname); err != nil {
+			return err
+		}
+		if !oid.Valid || !spcname.Valid {
+			continue
+		}
+		tablespaces = append(tablespaces, tablespace{oid: oid.Int64, name: spcname.String})
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, ts := range tablespaces {
+		var size sql.NullFloat64
+		err := db.QueryRowContext(ctx, pgTablespaceSizeQuery, ts.oid).Scan(&size)
+		if err != nil {
+			if isInsufficientPrivilege(err) {
+				c.log.Warn("Skipping tablespace size, permission denied", "spcname", ts.name, "err", err)
+				continue
+			}
+			return err
+		}
+
+		sizeMetric := 0.0
+		if size.Valid {
+			sizeMetric = size.Float64
+		}
+		ch <- prometheus.MustNewConstMetric(
+			pgTablespaceSizeDesc,
+			prometheus.GaugeValue, sizeMetric, ts.name,
+		)
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package collector
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/promslog"
+	"github.com/smartystreets/goconvey/convey"
+)
+
+func TestPGTablespacesCollector(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error opening a stub db connection: %s", err)
+	}
+	defer db.Close()
+
+	inst := &Instance{db: db}
+
+	mock.ExpectQuery(sanitizeQuery(pgTablespacesQuery)).WillReturnRows(sqlmock.NewRows([]string{"oid", "spcname"}).
+		AddRow(1663, "pg_default").
+		AddRow(16400, "fast_ssd"))
+	mock.ExpectQuery(sanitizeQuery(pgTablespaceSizeQuery)).WithArgs(1663).WillReturnRows(sqlmock.NewRows([]string{"pg_tablespace_size"}).
+		AddRow(8388608))
+	mock.ExpectQuery(sanitizeQuery(pgTablespaceSizeQuery)).WithArgs(16400).WillReturnRows(sqlmock.NewRows([]string{"pg_tablespace_size"}).
+		AddRow(1073741824))
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		defer close(ch)
+		c := PGTablespacesCollector{}
+
+		if err := c.Update(context.Background(), inst, ch); err != nil {
+			t.Errorf("Error calling PGTablespacesCollector.Update: %s", err)
+		}
+	}()
+
+	expected := []MetricResult{
+		{labels: labelMap{"spcname": "pg_default"}, value: 8388608, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"spcname": "fast_ssd"}, value: 1073741824, metricType: dto.MetricType_GAUGE},
+	}
+
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range expected {
+			m := readMetric(<-ch)
+			convey.So(expect, convey.ShouldResemble, m)
+		}
+	})
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
+
+func TestPGTablespacesCollectorPermissionDenied(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error opening a stub db connection: %s", err)
+	}
+	defer db.Close()
+
+	inst := &Instance{db: db}
+
+	mock.ExpectQuery(sanitizeQuery(pgTablespacesQuery)).WillReturnRows(sqlmock.NewRows([]string{"oid", "spcname"}).
+		AddRow(16400, "restricted").
+		AddRow(1663, "pg_default"))
+	mock.ExpectQuery(sanitizeQuery(pgTablespaceSizeQuery)).WithArgs(16400).
+		WillReturnError(&pq.Error{Code: "42501", Message: "permission denied for tablespace restricted"})
+	mock.ExpectQuery(sanitizeQuery(pgTablespaceSizeQuery)).WithArgs(1663).WillReturnRows(sqlmock.NewRows([]string{"pg_tablespace_size"}).
+		AddRow(8388608))
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		defer close(ch)
+		c := PGTablespacesCollector{log: promslog.NewNopLogger()}
+
+		if err := c.Update(context.Background(), inst, ch); err != nil {
+			t.Errorf("Error calling PGTablespacesCollector.Update: %s", err)
+		}
+	}()
+
+	expected := []MetricResult{
+		{labels: labelMap{"spcname": "pg_default"}, value: 8388608, metricType: dto.MetricType_GAUGE},
+	}
+
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range expected {
+			m := readMetric(<-ch)
+			convey.So(expect, convey.ShouldResemble, m)
+		}
+	})
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
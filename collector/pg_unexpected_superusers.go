@@ -16,26 +16,52 @@ package collector
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"log/slog"
+	"sync"
+	"time"
 
+	"github.com/alecthomas/kingpin/v2"
 	"github.com/blang/semver/v4"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
 const unexpectedSuperusersSubsystem = "unexpected_superusers"
 
+var unexpectedSuperusersGracePeriodFlag *time.Duration = nil
+
 func init() {
 	registerCollector(unexpectedSuperusersSubsystem, defaultEnabled, NewPGUnexpectedSuperusersCollector)
+
+	unexpectedSuperusersGracePeriodFlag = kingpin.Flag(
+		fmt.Sprint(collectorFlagPrefix, unexpectedSuperusersSubsystem, ".grace-period"),
+		"How long a newly observed unexpected superuser is reported as pending before it is reported as unexpected.").
+		Default("0s").
+		Duration()
 }
 
+// PGUnexpectedSuperusersCollector reports superuser roles that are not in the
+// expected list. With a grace period configured, a role is first reported as
+// pending and only moves to the role series once it has been observed for
+// longer than the grace period, so the collector remembers when each role was
+// first seen across scrapes. Collectors are shared between /metrics and every
+// /probe target, so that state is kept per instance DSN.
 type PGUnexpectedSuperusersCollector struct {
-	log *slog.Logger
+	log         *slog.Logger
+	gracePeriod time.Duration
+
+	mu        sync.Mutex
+	firstSeen map[string]map[string]time.Time
 }
 
 func NewPGUnexpectedSuperusersCollector(config collectorConfig) (Collector, error) {
-	return &PGUnexpectedSuperusersCollector{
+	c := &PGUnexpectedSuperusersCollector{
 		log: config.logger,
-	}, nil
+	}
+	if unexpectedSuperusersGracePeriodFlag != nil {
+		c.gracePeriod = *unexpectedSuperusersGracePeriodFlag
+	}
+	return c, nil
 }
 
 var (
@@ -59,6 +85,16 @@ var (
 		[]string{"rolname", "access_type"}, nil,
 	)
 
+	pgUnexpectedSuperuserPendingDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(
+			namespace,
+			unexpectedSuperusersSubsystem,
+			"pending",
+		),
+		"Unexpected superuser role still within the grace period (value is always 1)",
+		[]string{"rolname"}, nil,
+	)
+
 	// Roles that are expected to have superuser privileges.
 	expectedSuperusers = map[string]struct{}{
 		"pscale_admin": {},
@@ -89,7 +125,24 @@ FROM superuser_oids so
 JOIN pg_catalog.pg_roles r ON r.oid OPERATOR(pg_catalog.=) so.oid`
 )
 
-func (c PGUnexpectedSuperusersCollector) Update(ctx context.Context, instance *Instance, ch chan<- prometheus.Metric) error {
+// pending records rolname as observed at now in the target's firstSeen and
+// reports whether it is still within the grace period. Roles missing from seen
+// are forgotten, so a role that loses and later regains superuser starts a new
+// grace period.
+func (c *PGUnexpectedSuperusersCollector) pending(firstSeen map[string]time.Time, seen map[string]struct{}, rolname string, now time.Time) bool {
+	if c.gracePeriod <= 0 {
+		return false
+	}
+	first, ok := firstSeen[rolname]
+	if !ok {
+		first = now
+		firstSeen[rolname] = now
+	}
+	seen[rolname] = struct{}{}
+	return now.Sub(first) < c.gracePeriod
+}
+
+func (c *PGUnexpectedSuperusersCollector) Update(ctx context.Context, instance *Instance, ch chan<- prometheus.Metric) error {
 	db := instance.getDB()
 
 	query := pgUnexpectedSuperusersQuery
@@ -103,12 +156,25 @@ func (c PGUnexpectedSuperusersCollector) Update(ctx context.Context, instance *I
 		}
 	}
 
-	rows, err := db.QueryContext(ctx, query)
+	rows, err := queryWithOverride(ctx, db, unexpectedSuperusersSubsystem, query, 2)
 	if err != nil {
 		return err
 	}
 	defer rows.Close()
 
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.firstSeen == nil {
+		c.firstSeen = map[string]map[string]time.Time{}
+	}
+	firstSeen, ok := c.firstSeen[instance.dsn]
+	if !ok {
+		firstSeen = map[string]time.Time{}
+		c.firstSeen[instance.dsn] = firstSeen
+	}
+	now := time.Now()
+	seen := map[string]struct{}{}
+
 	var count float64
 	for rows.Next() {
 		var rolname sql.NullString
@@ -125,6 +191,14 @@ func (c PGUnexpectedSuperusersCollector) Update(ctx context.Context, instance *I
 			continue
 		}
 
+		if c.pending(firstSeen, seen, rolname.String, now) {
+			ch <- prometheus.MustNewConstMetric(
+				pgUnexpectedSuperuserPendingDesc,
+				prometheus.GaugeValue, 1, rolname.String,
+			)
+			continue
+		}
+
 		accessTypeLabel := "direct"
 		if accessType.Valid {
 			accessTypeLabel = accessType.String
@@ -141,6 +215,12 @@ func (c PGUnexpectedSuperusersCollector) Update(ctx context.Context, instance *I
 		return err
 	}
 
+	for rolname := range firstSeen {
+		if _, ok := seen[rolname]; !ok {
+			delete(firstSeen, rolname)
+		}
+	}
+
 	ch <- prometheus.MustNewConstMetric(
 		pgUnexpectedSuperusersDesc,
 		prometheus.GaugeValue, count,
@@ -15,6 +15,7 @@ package collector
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/blang/semver/v4"
@@ -297,3 +298,110 @@ func TestPGUnexpectedSuperusersCollectorLargeRolesFallbackPG16(t *testing.T) {
 		t.Errorf("there were unfulfilled expectations: %s", err)
 	}
 }
+
+func TestPGUnexpectedSuperusersCollectorGracePeriod(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error opening a stub db connection: %s", err)
+	}
+	defer db.Close()
+
+	inst := &Instance{db: db, version: semver.MustParse("15.0.0")}
+	c := &PGUnexpectedSuperusersCollector{gracePeriod: 10 * time.Minute}
+
+	scrape := func(expected []MetricResult) {
+		mock.ExpectQuery(sanitizeQuery(pgUnexpectedSuperusersQuery)).
+			WillReturnRows(sqlmock.NewRows([]string{"rolname", "access_type"}).
+				AddRow("pscale_admin", "direct").
+				AddRow("maintenance_admin", "direct"))
+
+		ch := make(chan prometheus.Metric)
+		go func() {
+			defer close(ch)
+			if err := c.Update(context.Background(), inst, ch); err != nil {
+				t.Errorf("Error calling PGUnexpectedSuperusersCollector.Update: %s", err)
+			}
+		}()
+
+		for _, expect := range expected {
+			m := readMetric(<-ch)
+			convey.So(expect, convey.ShouldResemble, m)
+		}
+	}
+
+	convey.Convey("Newly observed superuser is pending within the grace period", t, func() {
+		scrape([]MetricResult{
+			{labels: labelMap{"rolname": "maintenance_admin"}, value: 1, metricType: dto.MetricType_GAUGE},
+			{labels: labelMap{}, value: 0, metricType: dto.MetricType_GAUGE},
+		})
+	})
+
+	c.firstSeen[inst.dsn]["maintenance_admin"] = time.Now().Add(-11 * time.Minute)
+
+	convey.Convey("Superuser persisting past the grace period is unexpected", t, func() {
+		scrape([]MetricResult{
+			{labels: labelMap{"rolname": "maintenance_admin", "access_type": "direct"}, value: 1, metricType: dto.MetricType_GAUGE},
+			{labels: labelMap{}, value: 1, metricType: dto.MetricType_GAUGE},
+		})
+	})
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestPGUnexpectedSuperusersCollectorGracePeriodPerTarget(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error opening a stub db connection: %s", err)
+	}
+	defer db.Close()
+
+	instA := &Instance{dsn: "host=db-a", db: db, version: semver.MustParse("15.0.0")}
+	instB := &Instance{dsn: "host=db-b", db: db, version: semver.MustParse("15.0.0")}
+	c := &PGUnexpectedSuperusersCollector{gracePeriod: 10 * time.Minute}
+
+	scrape := func(inst *Instance, rolname string, expected []MetricResult) {
+		mock.ExpectQuery(sanitizeQuery(pgUnexpectedSuperusersQuery)).
+			WillReturnRows(sqlmock.NewRows([]string{"rolname", "access_type"}).
+				AddRow(rolname, "direct"))
+
+		ch := make(chan prometheus.Metric)
+		go func() {
+			defer close(ch)
+			if err := c.Update(context.Background(), inst, ch); err != nil {
+				t.Errorf("Error calling PGUnexpectedSuperusersCollector.Update: %s", err)
+			}
+		}()
+
+		for _, expect := range expected {
+			m := readMetric(<-ch)
+			convey.So(expect, convey.ShouldResemble, m)
+		}
+	}
+
+	convey.Convey("Alternating targets keep their own first-seen state", t, func() {
+		scrape(instA, "admin_a", []MetricResult{
+			{labels: labelMap{"rolname": "admin_a"}, value: 1, metricType: dto.MetricType_GAUGE},
+			{labels: labelMap{}, value: 0, metricType: dto.MetricType_GAUGE},
+		})
+		scrape(instB, "admin_b", []MetricResult{
+			{labels: labelMap{"rolname": "admin_b"}, value: 1, metricType: dto.MetricType_GAUGE},
+			{labels: labelMap{}, value: 0, metricType: dto.MetricType_GAUGE},
+		})
+
+		c.firstSeen[instA.dsn]["admin_a"] = time.Now().Add(-11 * time.Minute)
+		c.firstSeen[instB.dsn]["admin_b"] = time.Now().Add(-11 * time.Minute)
+
+		scrape(instA, "admin_a", []MetricResult{
+			{labels: labelMap{"rolname": "admin_a", "access_type": "direct"}, value: 1, metricType: dto.MetricType_GAUGE},
+			{labels: labelMap{}, value: 1, metricType: dto.MetricType_GAUGE},
+		})
+		scrape(instB, "admin_b", []MetricResult{
+			{labels: labelMap{"rolname": "admin_b", "access_type": "direct"}, value: 1, metricType: dto.MetricType_GAUGE},
+			{labels: labelMap{}, value: 1, metricType: dto.MetricType_GAUGE},
+		})
+	})
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
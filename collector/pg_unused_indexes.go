@@ -0,0 +1,130 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const unusedIndexesSubsystem = "unused_indexes"
+
+var (
+	unusedIndexesIgnoreUniqueFlag      *bool = nil
+	unusedIndexesIgnoreConstraintsFlag *bool = nil
+)
+
+func init() {
+	registerCollector(unusedIndexesSubsystem, defaultDisabled, NewPGUnusedIndexesCollector)
+
+	unusedIndexesIgnoreUniqueFlag = kingpin.Flag(
+		fmt.Sprint(collectorFlagPrefix, unusedIndexesSubsystem, ".ignore-unique"),
+		"Do not report unique indexes, which enforce uniqueness even if never scanned.").
+		Default("true").
+		Bool()
+	unusedIndexesIgnoreConstraintsFlag = kingpin.Flag(
+		fmt.Sprint(collectorFlagPrefix, unusedIndexesSubsystem, ".ignore-constraints"),
+		"Do not report indexes backing a primary key, unique or exclusion constraint.").
+		Default("true").
+		Bool()
+}
+
+// PGUnusedIndexesCollector reports valid indexes that have not been scanned
+// since statistics were last reset. They still cost space and write
+// amplification, so they are candidates for removal once the statistics
+// cover a representative period.
+type PGUnusedIndexesCollector struct {
+	log               *slog.Logger
+	ignoreUnique      bool
+	ignoreConstraints bool
+}
+
+func NewPGUnusedIndexesCollector(config collectorConfig) (Collector, error) {
+	return &PGUnusedIndexesCollector{
+		log:               config.logger,
+		ignoreUnique:      *unusedIndexesIgnoreUniqueFlag,
+		ignoreConstraints: *unusedIndexesIgnoreConstraintsFlag,
+	}, nil
+}
+
+var (
+	pgUnusedIndexSizeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "unused_index", "size_bytes"),
+		"Size of an index that has not been scanned since statistics were last reset",
+		[]string{"datname", "schemaname", "relname", "indexrelname"}, nil,
+	)
+	pgUnusedIndexStatsAgeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "unused_index", "stats_age_seconds"),
+		"Seconds since the database statistics were last reset, i.e. how long the index has gone unscanned",
+		[]string{"datname", "schemaname", "relname", "indexrelname"}, nil,
+	)
+
+	pgUnusedIndexesQuery = `SELECT
+		current_database() AS datname,
+		s.schemaname,
+		s.relname,
+		s.indexrelname,
+		pg_relation_size(s.indexrelid) AS size_bytes,
+		EXTRACT(EPOCH FROM now() - d.stats_reset) AS stats_age_seconds
+	FROM pg_catalog.pg_stat_user_indexes s
+	JOIN pg_catalog.pg_index i ON i.indexrelid = s.indexrelid
+	LEFT JOIN pg_catalog.pg_stat_database d ON d.datname = current_database()
+	WHERE s.idx_scan = 0
+		AND i.indisvalid
+		AND NOT ($1 AND i.indisunique)
+		AND NOT ($2 AND EXISTS (
+			SELECT 1 FROM pg_catalog.pg_constraint c WHERE c.conindid = s.indexrelid
+		))`
+)
+
+func (c PGUnusedIndexesCollector) Update(ctx context.Context, instance *Instance, ch chan<- prometheus.Metric) error {
+	db := instance.getDB()
+	rows, err := queryWithOverride(ctx, db, unusedIndexesSubsystem, pgUnusedIndexesQuery, 6, c.ignoreUnique, c.ignoreConstraints)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var datname, schemaname, relname, indexrelname sql.NullString
+		var sizeBytes sql.NullInt64
+		var statsAge sql.NullFloat64
+		if err := rows.Scan(&datname, &schemaname, &relname, &indexrelname, &sizeBytes, &statsAge); err != nil {
+			return err
+		}
+
+		if !datname.Valid || !schemaname.Valid || !relname.Valid || !indexrelname.Valid {
+			continue
+		}
+		labels := []string{datname.String, schemaname.String, relname.String, indexrelname.String}
+
+		ch <- prometheus.MustNewConstMetric(
+			pgUnusedIndexSizeDesc,
+			prometheus.GaugeValue, float64(sizeBytes.Int64), labels...,
+		)
+		// stats_reset is NULL until statistics are reset for the first time.
+		if statsAge.Valid {
+			ch <- prometheus.MustNewConstMetric(
+				pgUnusedIndexStatsAgeDesc,
+				prometheus.GaugeValue, statsAge.Float64, labels...,
+			)
+		}
+	}
+	return rows.Err()
+}
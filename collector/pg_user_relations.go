@@ -0,0 +1,89 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const userRelationsSubsystem = "user_relations"
+
+func init() {
+	registerCollector(userRelationsSubsystem, defaultDisabled, NewPGUserRelationsCollector)
+}
+
+// PGUserRelationsCollector counts the user tables and indexes in the
+// connected database. A steadily climbing count usually means runaway
+// partition creation, which inflates catalog size and planning time.
+type PGUserRelationsCollector struct {
+	log *slog.Logger
+}
+
+func NewPGUserRelationsCollector(config collectorConfig) (Collector, error) {
+	return &PGUserRelationsCollector{log: config.logger}, nil
+}
+
+var (
+	pgUserTablesCountDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "user_tables", "count"),
+		"Number of user tables in the database",
+		[]string{"datname"}, nil,
+	)
+	pgUserIndexesCountDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "user_indexes", "count"),
+		"Number of user indexes in the database",
+		[]string{"datname"}, nil,
+	)
+
+	pgUserRelationsQuery = `SELECT
+		current_database() AS datname,
+		(SELECT count(*) FROM pg_catalog.pg_stat_user_tables) AS tables,
+		(SELECT count(*) FROM pg_catalog.pg_stat_user_indexes) AS indexes`
+)
+
+func (c PGUserRelationsCollector) Update(ctx context.Context, instance *Instance, ch chan<- prometheus.Metric) error {
+	db := instance.getDB()
+
+	var datname sql.NullString
+	var tables, indexes sql.NullInt64
+	if err := scanRowWithOverride(ctx, db, userRelationsSubsystem, pgUserRelationsQuery, nil, &datname, &tables, &indexes); err != nil {
+		return err
+	}
+	if !datname.Valid {
+		return nil
+	}
+
+	tablesMetric := 0.0
+	if tables.Valid {
+		tablesMetric = float64(tables.Int64)
+	}
+	ch <- prometheus.MustNewConstMetric(
+		pgUserTablesCountDesc,
+		prometheus.GaugeValue, tablesMetric, datname.String,
+	)
+
+	indexesMetric := 0.0
+	if indexes.Valid {
+		indexesMetric = float64(indexes.Int64)
+	}
+	ch <- prometheus.MustNewConstMetric(
+		pgUserIndexesCountDesc,
+		prometheus.GaugeValue, indexesMetric, datname.String,
+	)
+	return nil
+}
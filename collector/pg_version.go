@@ -0,0 +1,54 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const versionSubsystem = "version"
+
+func init() {
+	registerCollector(versionSubsystem, defaultDisabled, NewPGVersionCollector)
+}
+
+// PGVersionCollector reports the server version detected when the instance
+// was set up. It does not query the database.
+type PGVersionCollector struct {
+	log *slog.Logger
+}
+
+func NewPGVersionCollector(config collectorConfig) (Collector, error) {
+	return &PGVersionCollector{log: config.logger}, nil
+}
+
+var pgVersionInfoDesc = prometheus.NewDesc(
+	prometheus.BuildFQName(namespace, "version", "info"),
+	"PostgreSQL server version (value is always 1). full_version is the raw banner the version was parsed from, empty when the version is overridden.",
+	[]string{"version", "short_version", "full_version"}, nil,
+)
+
+func (c PGVersionCollector) Update(ctx context.Context, instance *Instance, ch chan<- prometheus.Metric) error {
+	v := instance.version
+	ch <- prometheus.MustNewConstMetric(
+		pgVersionInfoDesc,
+		prometheus.GaugeValue, 1,
+		v.String(), fmt.Sprintf("%d.%d", v.Major, v.Minor), instance.versionString,
+	)
+	return nil
+}
@@ -0,0 +1,122 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/blang/semver/v4"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const waitsSubsystem = "waits"
+
+var waitsAlertThresholdFlag *uint = nil
+
+func init() {
+	registerCollector(waitsSubsystem, defaultDisabled, NewPGWaitsCollector)
+
+	waitsAlertThresholdFlag = kingpin.Flag(
+		fmt.Sprint(collectorFlagPrefix, waitsSubsystem, ".alert-threshold"),
+		"Report a wait event as saturated when more active backends than this are waiting on it. 0 disables the saturated series.").
+		Default("0").
+		Uint()
+}
+
+// PGWaitsCollector samples pg_stat_activity on every scrape and counts
+// active backends by the wait event they are currently waiting on, a
+// lightweight view of active session history that needs no extension. A
+// pile-up on a single wait stands out.
+type PGWaitsCollector struct {
+	log                     *slog.Logger
+	alertThreshold          uint
+	excludeApplicationNames []string
+	skipOnStandby           bool
+	roleLabel               bool
+}
+
+func NewPGWaitsCollector(config collectorConfig) (Collector, error) {
+	return &PGWaitsCollector{
+		log:                     config.logger,
+		alertThreshold:          *waitsAlertThresholdFlag,
+		excludeApplicationNames: excludeApplicationNames(),
+		skipOnStandby:           activitySkipOnStandby(),
+		roleLabel:               activityRoleLabel(),
+	}, nil
+}
+
+var (
+	pgWaitEventBackendsDesc = newActivityDesc(
+		prometheus.BuildFQName(namespace, "wait_event", "backends"),
+		"Number of active backends waiting on the wait event",
+		[]string{"wait_event_type", "wait_event"},
+	)
+	pgWaitEventSaturatedDesc = newActivityDesc(
+		prometheus.BuildFQName(namespace, "wait_event", "saturated"),
+		"More active backends than the alert threshold are waiting on the wait event (value is always 1)",
+		[]string{"wait_event_type", "wait_event"},
+	)
+
+	pgWaitsQueryBase = `SELECT
+		wait_event_type,
+		wait_event,
+		count(*) AS backends
+	FROM pg_catalog.pg_stat_activity`
+)
+
+func (c PGWaitsCollector) query() string {
+	q := newQueryBuilder(pgWaitsQueryBase).
+		where("state = 'active'").
+		where("wait_event IS NOT NULL").
+		where(excludeSelfCondition(c.excludeApplicationNames))
+	return terminateQuery(q.subquery() + "\nGROUP BY wait_event_type, wait_event\nORDER BY wait_event_type, wait_event")
+}
+
+func (c PGWaitsCollector) Update(ctx context.Context, instance *Instance, ch chan<- prometheus.Metric) error {
+	if instance.version.LT(semver.MustParse("9.6.0")) {
+		return skipUnsupportedVersion(c.log, "pg_stat_activity.wait_event is not available before PostgreSQL 9.6")
+	}
+
+	role, skip, err := activityRole(ctx, instance, c.skipOnStandby, c.roleLabel, c.log)
+	if err != nil || skip {
+		return err
+	}
+	db := instance.getDB()
+	rows, err := queryWithOverride(ctx, db, waitsSubsystem, c.query(), 3)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var waitEventType, name sql.NullString
+		var backends sql.NullInt64
+		if err := rows.Scan(&waitEventType, &name, &backends); err != nil {
+			return err
+		}
+		ch <- pgWaitEventBackendsDesc.mustNewConstMetric(
+			role, prometheus.GaugeValue, float64(backends.Int64), waitEventType.String, name.String,
+		)
+		if c.alertThreshold > 0 && backends.Int64 > int64(c.alertThreshold) {
+			ch <- pgWaitEventSaturatedDesc.mustNewConstMetric(
+				role, prometheus.GaugeValue, 1, waitEventType.String, name.String,
+			)
+		}
+	}
+	return rows.Err()
+}
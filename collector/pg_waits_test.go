@@ -0,0 +1,67 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package collector
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/blang/semver/v4"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+)
+
+func TestPGWaitsCollector(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error opening a stub db connection: %s", err)
+	}
+	defer db.Close()
+
+	inst := &Instance{db: db, version: semver.MustParse("16.0.0")}
+	c := PGWaitsCollector{alertThreshold: 3}
+
+	rows := sqlmock.NewRows([]string{"wait_event_type", "wait_event", "backends"}).
+		AddRow("IO", "DataFileRead", 5).
+		AddRow("IO", "WALWrite", 1).
+		AddRow("Lock", "transactionid", 1)
+	mock.ExpectQuery(sanitizeQuery(c.query())).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		defer close(ch)
+		if err := c.Update(context.Background(), inst, ch); err != nil {
+			t.Errorf("Error calling PGWaitsCollector.Update: %s", err)
+		}
+	}()
+
+	expected := []MetricResult{
+		{labels: labelMap{"wait_event_type": "IO", "wait_event": "DataFileRead"}, value: 5, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"wait_event_type": "IO", "wait_event": "DataFileRead"}, value: 1, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"wait_event_type": "IO", "wait_event": "WALWrite"}, value: 1, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{"wait_event_type": "Lock", "wait_event": "transactionid"}, value: 1, metricType: dto.MetricType_GAUGE},
+	}
+
+	convey.Convey("Metrics comparison", t, func() {
+		var metrics []MetricResult
+		for m := range ch {
+			metrics = append(metrics, readMetric(m))
+		}
+		convey.So(metrics, convey.ShouldResemble, expected)
+	})
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
@@ -62,13 +62,9 @@ var (
 
 func (c PGWALCollector) Update(ctx context.Context, instance *Instance, ch chan<- prometheus.Metric) error {
 	db := instance.getDB()
-	row := db.QueryRowContext(ctx,
-		pgWALQuery,
-	)
-
 	var segments uint64
 	var size uint64
-	err := row.Scan(&segments, &size)
+	err := scanRowWithOverride(ctx, db, walSubsystem, pgWALQuery, nil, &segments, &size)
 	if err != nil {
 		return err
 	}
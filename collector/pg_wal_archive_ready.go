@@ -0,0 +1,84 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+
+	"github.com/blang/semver/v4"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const walArchiveReadySubsystem = "wal_archive_ready"
+
+func init() {
+	registerCollector(walArchiveReadySubsystem, defaultDisabled, NewPGWALArchiveReadyCollector)
+}
+
+// PGWALArchiveReadyCollector counts WAL segments waiting for archive_command
+// to pick them up. A growing backlog means pg_wal will eventually fill up.
+type PGWALArchiveReadyCollector struct {
+	log *slog.Logger
+}
+
+func NewPGWALArchiveReadyCollector(config collectorConfig) (Collector, error) {
+	return &PGWALArchiveReadyCollector{log: config.logger}, nil
+}
+
+var (
+	pgWALArchiveReadyCountDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, walSubsystem, "archive_ready_count"),
+		"Number of WAL segments with a .ready file awaiting archiving",
+		[]string{}, nil,
+	)
+
+	pgWALArchiveReadyQuery = `SELECT count(*)
+	FROM pg_ls_dir('pg_wal/archive_status') AS f(name)
+	WHERE f.name LIKE '%.ready'`
+)
+
+func (c PGWALArchiveReadyCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- pgWALArchiveReadyCountDesc
+}
+
+func (c PGWALArchiveReadyCollector) Update(ctx context.Context, instance *Instance, ch chan<- prometheus.Metric) error {
+	// pg_xlog was renamed to pg_wal in PostgreSQL 10.
+	if instance.version.LT(semver.MustParse("10.0.0")) {
+		return skipUnsupportedVersion(c.log, "wal_archive_ready collector is not available on PostgreSQL < 10, skipping")
+	}
+
+	db := instance.getDB()
+
+	var count sql.NullInt64
+	if err := scanRowWithOverride(ctx, db, walArchiveReadySubsystem, pgWALArchiveReadyQuery, nil, &count); err != nil {
+		// pg_ls_dir is restricted to superusers unless explicitly granted.
+		if isInsufficientPrivilege(err) {
+			c.log.Debug("not permitted to list pg_wal/archive_status", "err", err)
+			return ErrNoData
+		}
+		return err
+	}
+
+	countMetric := 0.0
+	if count.Valid {
+		countMetric = float64(count.Int64)
+	}
+	ch <- prometheus.MustNewConstMetric(
+		pgWALArchiveReadyCountDesc,
+		prometheus.GaugeValue, countMetric,
+	)
+	return nil
+}
@@ -62,8 +62,7 @@ func (c PGXlogLocationCollector) Update(ctx context.Context, instance *Instance,
 		return nil
 	}
 
-	rows, err := db.QueryContext(ctx,
-		xlogLocationQuery)
+	rows, err := queryWithOverride(ctx, db, xlogLocationSubsystem, xlogLocationQuery, 1)
 
 	if err != nil {
 		return err
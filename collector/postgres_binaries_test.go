@@ -20,6 +20,7 @@ import (
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/prometheus/client_golang/prometheus"
 	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/promslog"
 	"github.com/smartystreets/goconvey/convey"
 )
 
@@ -229,3 +230,25 @@ func TestPostgresBinariesCollectorConnectionError(t *testing.T) {
 		t.Errorf("there were unfulfilled expectations: %s", err)
 	}
 }
+
+func TestPostgresBinariesCollectorRegistered(t *testing.T) {
+	if _, ok := factories[postgresBinariesSubsystem]; !ok {
+		t.Fatalf("collector %q is not registered", postgresBinariesSubsystem)
+	}
+	enabled, ok := collectorState[postgresBinariesSubsystem]
+	if !ok {
+		t.Fatalf("collector %q has no enable flag", postgresBinariesSubsystem)
+	}
+
+	orig := *enabled
+	defer func() { *enabled = orig }()
+	*enabled = false
+
+	pc, err := NewPostgresCollector(promslog.NewNopLogger(), nil, nil, []string{})
+	if err != nil {
+		t.Fatalf("Error creating PostgresCollector: %s", err)
+	}
+	if _, ok := pc.Collectors[postgresBinariesSubsystem]; ok {
+		t.Errorf("collector %q should not be instantiated when disabled", postgresBinariesSubsystem)
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"strings"
+)
+
+// queryBuilder assembles a query from a base SELECT and a set of optional
+// WHERE conditions. Fragments may be written with or without a trailing
+// semicolon; the builder strips them so the rendered query never ends up
+// with a stray ";" in the middle or a doubled ";;" at the end.
+type queryBuilder struct {
+	base       string
+	conditions []string
+}
+
+// newQueryBuilder returns a builder for base, which must not contain a WHERE
+// clause of its own.
+func newQueryBuilder(base string) *queryBuilder {
+	return &queryBuilder{base: trimQuery(base)}
+}
+
+// where adds a condition that is ANDed with the others. Empty conditions are
+// ignored so callers can pass flag-dependent fragments unconditionally.
+func (b *queryBuilder) where(condition string) *queryBuilder {
+	condition = trimQuery(condition)
+	if condition != "" {
+		b.conditions = append(b.conditions, condition)
+	}
+	return b
+}
+
+// subquery renders the query without a terminating semicolon, for embedding
+// in another statement.
+func (b *queryBuilder) subquery() string {
+	if len(b.conditions) == 0 {
+		return b.base
+	}
+	return b.base + "\nWHERE " + strings.Join(b.conditions, "\n  AND ")
+}
+
+// String renders the query as a statement terminated by exactly one semicolon.
+func (b *queryBuilder) String() string {
+	return terminateQuery(b.subquery())
+}
+
+// terminateQuery returns q with any trailing semicolons and whitespace
+// replaced by a single semicolon.
+func terminateQuery(q string) string {
+	return trimQuery(q) + ";"
+}
+
+// trimQuery removes surrounding whitespace and trailing semicolons from q.
+func trimQuery(q string) string {
+	q = strings.TrimSpace(q)
+	for strings.HasSuffix(q, ";") {
+		q = strings.TrimSpace(strings.TrimSuffix(q, ";"))
+	}
+	return q
+}
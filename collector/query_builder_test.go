@@ -0,0 +1,88 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package collector
+
+import (
+	"strings"
+	"testing"
+)
+
+// assertSingleTerminator fails unless q contains exactly one semicolon, at the
+// very end.
+func assertSingleTerminator(t *testing.T, q string) {
+	t.Helper()
+	if strings.Count(q, ";") != 1 || !strings.HasSuffix(q, ";") {
+		t.Errorf("query must contain exactly one trailing semicolon: %q", q)
+	}
+}
+
+func TestQueryBuilder(t *testing.T) {
+	tests := []struct {
+		name       string
+		base       string
+		conditions []string
+		want       string
+	}{
+		{
+			name: "no conditions",
+			base: "SELECT 1 FROM pg_stat_activity",
+			want: "SELECT 1 FROM pg_stat_activity;",
+		},
+		{
+			name: "base with trailing semicolon",
+			base: "SELECT 1 FROM pg_stat_activity;\n",
+			want: "SELECT 1 FROM pg_stat_activity;",
+		},
+		{
+			name:       "conditions with semicolons and blanks",
+			base:       "SELECT 1 FROM pg_stat_activity;",
+			conditions: []string{"state = 'active';", "", "  ;", "xact_start IS NOT NULL ;;"},
+			want:       "SELECT 1 FROM pg_stat_activity\nWHERE state = 'active'\n  AND xact_start IS NOT NULL;",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := newQueryBuilder(tt.base)
+			for _, c := range tt.conditions {
+				b.where(c)
+			}
+			got := b.String()
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+			assertSingleTerminator(t, got)
+			if strings.Contains(b.subquery(), ";") {
+				t.Errorf("subquery must not contain a semicolon: %q", b.subquery())
+			}
+		})
+	}
+}
+
+func TestComposedQueriesTerminated(t *testing.T) {
+	queries := map[string]string{
+		"long_running_transactions":                           PGLongRunningTransactionsCollector{}.query(),
+		"long_running_transactions include-autovacuum":        PGLongRunningTransactionsCollector{includeAutovacuum: true}.query(),
+		"long_running_transactions exclude-application-names": PGLongRunningTransactionsCollector{excludeApplicationNames: []string{"pgbouncer"}}.query(),
+		"long_running_queries":                                PGLongRunningQueriesCollector{excludeApplicationNames: []string{"pgbouncer"}}.query(),
+		"backend_age":                                         PGBackendAgeCollector{excludeApplicationNames: []string{"pgbouncer"}}.query(),
+		"longest_active_query":                                PGLongestActiveQueryCollector{excludeApplicationNames: []string{"pgbouncer"}}.query(),
+		"stat_database":                                       statDatabaseQuery([]string{"datid", "datname"}),
+		"stat_wal":                                            statWALQuery([]string{"wal_records", "wal_fpi"}),
+	}
+	for name, q := range queries {
+		t.Run(name, func(t *testing.T) {
+			assertSingleTerminator(t, q)
+		})
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	queryOverridesMtx = sync.RWMutex{}
+	queryOverrides    = make(map[string]string)
+	// validatedOverrides records collectors whose override has already been
+	// checked against the expected columns.
+	validatedOverrides = sync.Map{}
+)
+
+// LoadQueryOverrides reads a YAML file mapping collector names to replacement
+// SQL and installs it as the query override registry. Each collector runs its
+// main query through queryWithOverride or scanRowWithOverride, so the
+// replacement is used instead of the built-in query. Helper queries, such as
+// checking whether an extension is installed, are not overridden.
+func LoadQueryOverrides(file string) error {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return fmt.Errorf("error reading query overrides file %q: %w", file, err)
+	}
+
+	overrides := make(map[string]string)
+	if err := yaml.Unmarshal(content, &overrides); err != nil {
+		return fmt.Errorf("error parsing query overrides file %q: %w", file, err)
+	}
+	for name, query := range overrides {
+		if _, ok := factories[name]; !ok {
+			return fmt.Errorf("query override for unknown collector %q", name)
+		}
+		if trimQuery(query) == "" {
+			return fmt.Errorf("query override for collector %q is empty", name)
+		}
+	}
+
+	setQueryOverrides(overrides)
+	return nil
+}
+
+func setQueryOverrides(overrides map[string]string) {
+	queryOverridesMtx.Lock()
+	defer queryOverridesMtx.Unlock()
+	queryOverrides = overrides
+	validatedOverrides.Clear()
+}
+
+// queryOverride returns the configured replacement query for a collector.
+func queryOverride(name string) (string, bool) {
+	queryOverridesMtx.RLock()
+	defer queryOverridesMtx.RUnlock()
+	q, ok := queryOverrides[name]
+	return q, ok
+}
+
+// queryWithOverride runs the override query for the named collector if one
+// is configured, and defaultQuery otherwise. Collectors scan their rows by
+// position, so the first time an override is used it must return exactly
+// columns columns; a replacement that would be scanned into the wrong fields
+// fails the collector, and is logged as such, instead of producing bogus
+// metrics.
+func queryWithOverride(ctx context.Context, db *sql.DB, name string, defaultQuery string, columns int, args ...any) (*sql.Rows, error) {
+	query, ok := queryOverride(name)
+	if !ok {
+		return db.QueryContext(ctx, defaultQuery, args...)
+	}
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query override for collector %q failed: %w", name, err)
+	}
+	if _, checked := validatedOverrides.Load(name); checked {
+		return rows, nil
+	}
+
+	got, err := rows.Columns()
+	if err != nil {
+		rows.Close()
+		return nil, err
+	}
+	if len(got) != columns {
+		rows.Close()
+		return nil, fmt.Errorf("invalid query override for collector %q: it returned %d columns %v, expected %d", name, len(got), got, columns)
+	}
+	validatedOverrides.Store(name, struct{}{})
+	return rows, nil
+}
+
+// scanRowWithOverride is the single row form of queryWithOverride. Like
+// sql.Row.Scan it copies the first row into dest and returns sql.ErrNoRows
+// if there is none.
+func scanRowWithOverride(ctx context.Context, db *sql.DB, name string, defaultQuery string, args []any, dest ...any) error {
+	rows, err := queryWithOverride(ctx, db, name, defaultQuery, len(dest), args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return err
+		}
+		return sql.ErrNoRows
+	}
+	if err := rows.Scan(dest...); err != nil {
+		return err
+	}
+	return rows.Close()
+}
@@ -0,0 +1,160 @@
+// Copyright 2025 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package collector
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/promslog"
+	"github.com/smartystreets/goconvey/convey"
+)
+
+const longRunningTransactionsOverrideSQL = "SELECT count(*) AS transactions, 0 AS oldest_timestamp_seconds FROM my_activity_view"
+
+func writeQueryOverrides(t *testing.T, content string) string {
+	t.Helper()
+	file := filepath.Join(t.TempDir(), "overrides.yml")
+	if err := os.WriteFile(file, []byte(content), 0o600); err != nil {
+		t.Fatalf("Error writing overrides file: %s", err)
+	}
+	t.Cleanup(func() { setQueryOverrides(map[string]string{}) })
+	return file
+}
+
+func TestQueryOverrideLongRunningTransactions(t *testing.T) {
+	file := writeQueryOverrides(t, "long_running_transactions: "+longRunningTransactionsOverrideSQL+"\n")
+	if err := LoadQueryOverrides(file); err != nil {
+		t.Fatalf("Error loading overrides: %s", err)
+	}
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error opening a stub db connection: %s", err)
+	}
+	defer db.Close()
+
+	inst := &Instance{db: db}
+
+	rows := sqlmock.NewRows([]string{"transactions", "oldest_timestamp_seconds"}).
+		AddRow(7, 0)
+	mock.ExpectQuery(sanitizeQuery(longRunningTransactionsOverrideSQL)).WillReturnRows(rows)
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		defer close(ch)
+		c := PGLongRunningTransactionsCollector{log: promslog.NewNopLogger()}
+
+		if err := c.Update(context.Background(), inst, ch); err != nil {
+			t.Errorf("Error calling PGLongRunningTransactionsCollector.Update: %s", err)
+		}
+	}()
+
+	expected := []MetricResult{
+		{labels: labelMap{}, value: 7, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{}, value: 0, metricType: dto.MetricType_GAUGE},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range expected {
+			m := readMetric(<-ch)
+			convey.So(expect, convey.ShouldResemble, m)
+		}
+	})
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
+
+func TestQueryOverrideColumnMismatch(t *testing.T) {
+	file := writeQueryOverrides(t, "long_running_transactions: SELECT 1 AS n\n")
+	if err := LoadQueryOverrides(file); err != nil {
+		t.Fatalf("Error loading overrides: %s", err)
+	}
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error opening a stub db connection: %s", err)
+	}
+	defer db.Close()
+
+	inst := &Instance{db: db}
+
+	mock.ExpectQuery("SELECT 1 AS n").WillReturnRows(sqlmock.NewRows([]string{"n"}).AddRow(1))
+
+	ch := make(chan prometheus.Metric, 2)
+	c := PGLongRunningTransactionsCollector{log: promslog.NewNopLogger()}
+	if err := c.Update(context.Background(), inst, ch); err == nil {
+		t.Error("expected an error for an override with mismatched columns")
+	}
+	if len(ch) != 0 {
+		t.Errorf("expected no metrics, got %d", len(ch))
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
+
+func TestLoadQueryOverridesUnknownCollector(t *testing.T) {
+	file := writeQueryOverrides(t, "no_such_collector: SELECT 1\n")
+	if err := LoadQueryOverrides(file); err == nil {
+		t.Error("expected an error for an override of an unknown collector")
+	}
+}
+
+func TestQueryOverridePostmaster(t *testing.T) {
+	const overrideSQL = "SELECT start_time_seconds, uptime_seconds FROM my_postmaster_view"
+	file := writeQueryOverrides(t, "postmaster: "+overrideSQL+"\n")
+	if err := LoadQueryOverrides(file); err != nil {
+		t.Fatalf("Error loading overrides: %s", err)
+	}
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error opening a stub db connection: %s", err)
+	}
+	defer db.Close()
+
+	inst := &Instance{db: db}
+
+	mock.ExpectQuery(sanitizeQuery(overrideSQL)).WillReturnRows(sqlmock.NewRows([]string{"start_time_seconds", "uptime_seconds"}).
+		AddRow(1685739904, 60))
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		defer close(ch)
+		c := PGPostmasterCollector{}
+
+		if err := c.Update(context.Background(), inst, ch); err != nil {
+			t.Errorf("Error calling PGPostmasterCollector.Update: %s", err)
+		}
+	}()
+
+	expected := []MetricResult{
+		{labels: labelMap{}, value: 1685739904, metricType: dto.MetricType_GAUGE},
+		{labels: labelMap{}, value: 60, metricType: dto.MetricType_GAUGE},
+	}
+	convey.Convey("Metrics comparison", t, func() {
+		for _, expect := range expected {
+			m := readMetric(<-ch)
+			convey.So(expect, convey.ShouldResemble, m)
+		}
+	})
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled exceptions: %s", err)
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"net/url"
 	"regexp"
 	"strings"
+	"time"
 	"unicode"
 )
 
@@ -175,6 +176,12 @@ func dsnFromKeyValue(in string) (DSN, error) {
 	return d, nil
 }
 
+// ParseKeyValue parses a key=value DSN, honoring quoted values such as the
+// options added by WithSessionTimeouts.
+func ParseKeyValue(in string) (map[string]string, error) {
+	return parseKeyValue(in)
+}
+
 // parseKeyValue is a key=value parser. It loops over each rune to split out keys and values
 // and attempting to honor quoted values. parseKeyValue will return an error if it is unable
 // to properly parse the input.
@@ -216,7 +223,7 @@ func parseKeyValue(in string) (map[string]string, error) {
 				part = []rune{}
 				// Do something with the value
 			}
-		case c == '=':
+		case c == '=' && !inQuote:
 			if inPart {
 				inPart = false
 				key = string(part)
@@ -236,3 +243,141 @@ func parseKeyValue(in string) (map[string]string, error) {
 
 	return out, nil
 }
+
+// sslModes are the sslmode values understood by the lib/pq driver.
+var sslModes = []string{"disable", "require", "verify-ca", "verify-full"}
+
+// ValidateSSLMode returns an error if mode is not an sslmode supported by the driver.
+func ValidateSSLMode(mode string) error {
+	for _, m := range sslModes {
+		if mode == m {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid sslmode %q, must be one of %s", mode, strings.Join(sslModes, ", "))
+}
+
+// WithDefaultSSLMode returns the connection string with sslmode set to mode, unless it already
+// specifies an sslmode. URL and key=value connection strings keep their original form.
+func WithDefaultSSLMode(in string, mode string) (string, error) {
+	if err := ValidateSSLMode(mode); err != nil {
+		return "", err
+	}
+
+	if strings.HasPrefix(in, "postgresql://") || strings.HasPrefix(in, "postgres://") {
+		u, err := url.Parse(in)
+		if err != nil {
+			return "", err
+		}
+		query := u.Query()
+		if query.Has("sslmode") {
+			return in, nil
+		}
+		query.Set("sslmode", mode)
+		u.RawQuery = query.Encode()
+		return u.String(), nil
+	}
+
+	if _, err := dsnFromKeyValue(in); err != nil {
+		return "", fmt.Errorf("could not understand DSN")
+	}
+	pairs, err := parseKeyValue(in)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse key-value DSN: %v", err)
+	}
+	if _, ok := pairs["sslmode"]; ok {
+		return in, nil
+	}
+	return fmt.Sprintf("%s sslmode=%s", strings.TrimSpace(in), mode), nil
+}
+
+// sessionTimeoutOptions returns the command-line options that set the given
+// timeouts on a session, or "" if neither is set.
+func sessionTimeoutOptions(lockTimeout, idleInTransactionTimeout time.Duration) string {
+	var opts []string
+	if lockTimeout > 0 {
+		opts = append(opts, fmt.Sprintf("-c lock_timeout=%d", lockTimeout.Milliseconds()))
+	}
+	if idleInTransactionTimeout > 0 {
+		opts = append(opts, fmt.Sprintf("-c idle_in_transaction_session_timeout=%d", idleInTransactionTimeout.Milliseconds()))
+	}
+	return strings.Join(opts, " ")
+}
+
+// appendOptions appends extra to the options connection parameter value.
+func appendOptions(options, extra string) string {
+	if options == "" {
+		return extra
+	}
+	return options + " " + extra
+}
+
+// WithSessionTimeouts returns the connection string with lock_timeout and
+// idle_in_transaction_session_timeout added to its options parameter, after
+// any options it already has, so that every connection opened with it gets
+// them. Zero durations are left out. URL and key=value connection strings
+// keep their original form.
+func WithSessionTimeouts(in string, lockTimeout, idleInTransactionTimeout time.Duration) (string, error) {
+	extra := sessionTimeoutOptions(lockTimeout, idleInTransactionTimeout)
+	if extra == "" {
+		return in, nil
+	}
+
+	if strings.HasPrefix(in, "postgresql://") || strings.HasPrefix(in, "postgres://") {
+		u, err := url.Parse(in)
+		if err != nil {
+			return "", err
+		}
+		query := u.Query()
+		query.Set("options", appendOptions(query.Get("options"), extra))
+		u.RawQuery = query.Encode()
+		return u.String(), nil
+	}
+
+	if _, err := dsnFromKeyValue(in); err != nil {
+		return "", fmt.Errorf("could not understand DSN")
+	}
+	pairs, err := parseKeyValue(in)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse key-value DSN: %v", err)
+	}
+	// A repeated keyword overrides the earlier one, so the existing options
+	// are carried over into the appended value.
+	options := appendOptions(pairs["options"], extra)
+	options = strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(options)
+	return fmt.Sprintf("%s options='%s'", strings.TrimSpace(in), options), nil
+}
+
+// WithSessionTimeouts returns a copy of d with the timeouts added to its
+// options parameter, as for the connection string form of WithSessionTimeouts.
+func (d DSN) WithSessionTimeouts(lockTimeout, idleInTransactionTimeout time.Duration) DSN {
+	extra := sessionTimeoutOptions(lockTimeout, idleInTransactionTimeout)
+	if extra == "" {
+		return d
+	}
+	d.query = d.cloneQuery()
+	d.query.Set("options", appendOptions(d.query.Get("options"), extra))
+	return d
+}
+
+// WithDefaultSSLMode returns a copy of d with sslmode set to mode, unless mode
+// is empty or d already specifies an sslmode. The mode is expected to have
+// been validated with ValidateSSLMode.
+func (d DSN) WithDefaultSSLMode(mode string) DSN {
+	if mode == "" || d.query.Has("sslmode") {
+		return d
+	}
+	d.query = d.cloneQuery()
+	d.query.Set("sslmode", mode)
+	return d
+}
+
+// cloneQuery returns a copy of the query parameters that can be modified
+// without affecting d.
+func (d DSN) cloneQuery() url.Values {
+	query := url.Values{}
+	for k, v := range d.query {
+		query[k] = append([]string(nil), v...)
+	}
+	return query
+}